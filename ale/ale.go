@@ -0,0 +1,292 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+// Package ale reads and writes Avid Log Exchange files.  The Start, End and Duration columns are
+// timecode.Timecode at the rate declared by the `FPS` or `VIDEO_FORMAT` heading field.  A timecode with
+// the `;` separator is drop frame.
+//
+// The heading fields and the columns other than Start, End and Duration are preserved as they are.
+package ale
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/wunderbarb/timecode"
+)
+
+var (
+	// ErrInvalidLine is returned when a line of the file cannot be parsed.
+	ErrInvalidLine = errors.New("ale: invalid line")
+	// ErrDuration is returned when Start plus Duration is not End.
+	ErrDuration = errors.New("ale: inconsistent duration")
+)
+
+const (
+	cHeading     = "Heading"
+	cColumn      = "Column"
+	cData        = "Data"
+	cFieldDelim  = "FIELD_DELIM"
+	cTabs        = "TABS"
+	cFPS         = "FPS"
+	cVideoFormat = "VIDEO_FORMAT"
+	cStart       = "Start"
+	cEnd         = "End"
+	cDuration    = "Duration"
+	cBits64      = 64
+)
+
+// ALE is an Avid Log Exchange file.
+type ALE struct {
+	// Heading are the fields of the heading in file order.
+	Heading []Field
+	// Columns are the names of the columns.
+	Columns []string
+	// Rows are the rows of the data section.
+	Rows []*Row
+	// CRLF is true if the lines end with carriage return and line feed.
+	CRLF bool
+	rate timecode.Rate
+}
+
+// Field is a field of the heading, e.g., `FPS` `25`.
+type Field struct {
+	Key   string
+	Value string
+}
+
+// Row is a row of the data section.
+type Row struct {
+	// Cells are the values of the row in column order.
+	Cells []string
+	// Start is the timecode of the Start column or nil if it is absent or empty.
+	Start *timecode.Timecode
+	// End is the timecode of the End column, i.e., the first frame after the clip, or nil if it is absent
+	// or empty.
+	End *timecode.Timecode
+	// Duration is the timecode of the Duration column or nil if it is absent or empty.
+	Duration *timecode.Timecode
+}
+
+// Read reads an Avid Log Exchange file.
+func Read(r io.Reader) (*ALE, error) {
+	a := &ALE{}
+	section := ""
+	rd := bufio.NewReader(r)
+	for n := 1; ; n++ {
+		line, err := rd.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if strings.HasSuffix(line, "\r\n") {
+			a.CRLF = true
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if perr := a.parseLine(&section, line); perr != nil {
+			return nil, errors.Wrapf(perr, "line %d", n)
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	if err := a.setTimecodes(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Rate returns the frame rate declared by the heading.  DropFrame is true if a timecode is drop frame.
+func (a *ALE) Rate() timecode.Rate {
+	return a.rate
+}
+
+// Get returns the value of the column `column` of the row `row` or an empty string if there is no such
+// column.
+func (a *ALE) Get(row *Row, column string) string {
+	i := a.column(column)
+	if i < 0 || i >= len(row.Cells) {
+		return ""
+	}
+	return row.Cells[i]
+}
+
+// Validate verifies that Start plus Duration is End for every row that has the three timecodes.
+func (a *ALE) Validate() error {
+	for i, row := range a.Rows {
+		if row.Start == nil || row.End == nil || row.Duration == nil {
+			continue
+		}
+		end := timecode.Clone(row.Start)
+		d, err := timecode.NewFromRate(row.Start.Rate(), row.Duration.Frame())
+		if err != nil {
+			return err
+		}
+		if err := end.Add(*d); err != nil {
+			return errors.Wrapf(err, "row %d", i+1)
+		}
+		if !end.Equal(*row.End) {
+			return errors.Wrapf(ErrDuration, "row %d: %v + %v is not %v", i+1, row.Start, row.Duration, row.End)
+		}
+	}
+	return nil
+}
+
+// Write writes the file.  The Start, End and Duration cells are written from the timecodes of the rows.
+func (a *ALE) Write(w io.Writer) error {
+	_, err := io.WriteString(w, a.String())
+	return err
+}
+
+// String returns the file as written by Write.
+func (a *ALE) String() string {
+	eol := "\n"
+	if a.CRLF {
+		eol = "\r\n"
+	}
+	var sb strings.Builder
+	sb.WriteString(cHeading + eol)
+	for _, f := range a.Heading {
+		sb.WriteString(f.Key + "\t" + f.Value + eol)
+	}
+	sb.WriteString(eol + cColumn + eol)
+	sb.WriteString(strings.Join(a.Columns, "\t") + eol)
+	sb.WriteString(eol + cData + eol)
+	for _, row := range a.Rows {
+		cells := append([]string(nil), row.Cells...)
+		cells = a.setCell(cells, cStart, row.Start)
+		cells = a.setCell(cells, cEnd, row.End)
+		cells = a.setCell(cells, cDuration, row.Duration)
+		sb.WriteString(strings.Join(cells, "\t") + eol)
+	}
+	return sb.String()
+}
+
+// parseLine parses the line `line` of the section `section` and updates the section.
+func (a *ALE) parseLine(section *string, line string) error {
+	switch line {
+	case cHeading, cColumn, cData:
+		*section = line
+		return nil
+	case "":
+		return nil
+	}
+	switch *section {
+	case cHeading:
+		k, v, _ := strings.Cut(line, "\t")
+		if k == cFieldDelim && v != cTabs {
+			return errors.Wrapf(ErrInvalidLine, "unsupported delimiter %q", v)
+		}
+		a.Heading = append(a.Heading, Field{Key: k, Value: v})
+	case cColumn:
+		if a.Columns != nil {
+			return errors.Wrapf(ErrInvalidLine, "second column line %q", line)
+		}
+		a.Columns = strings.Split(line, "\t")
+	case cData:
+		cells := strings.Split(line, "\t")
+		if len(cells) > len(a.Columns) {
+			return errors.Wrapf(ErrInvalidLine, "%d cells for %d columns", len(cells), len(a.Columns))
+		}
+		a.Rows = append(a.Rows, &Row{Cells: cells})
+	default:
+		return errors.Wrapf(ErrInvalidLine, "%q outside of a section", line)
+	}
+	return nil
+}
+
+// setTimecodes parses the Start, End and Duration cells of the rows.
+func (a *ALE) setTimecodes() error {
+	fps, err := a.fps()
+	if err != nil {
+		return err
+	}
+	a.rate = timecode.Rate{FPS: fps}
+	for i, row := range a.Rows {
+		for _, c := range []struct {
+			name string
+			tc   **timecode.Timecode
+		}{{cStart, &row.Start}, {cEnd, &row.End}, {cDuration, &row.Duration}} {
+			s := a.Get(row, c.name)
+			if s == "" {
+				continue
+			}
+			r := timecode.Rate{FPS: fps, DropFrame: strings.Contains(s, ";")}
+			tc, err := timecode.NewFromRate(r, 0)
+			if err == nil {
+				err = tc.Parse(s)
+			}
+			if err != nil {
+				return errors.Wrapf(err, "row %d: %s %q", i+1, c.name, s)
+			}
+			a.rate.DropFrame = a.rate.DropFrame || r.DropFrame
+			*c.tc = tc
+		}
+	}
+	return nil
+}
+
+// fps returns the frame rate declared by the FPS field or else by the VIDEO_FORMAT field.
+func (a *ALE) fps() (float64, error) {
+	switch v := a.heading(cFPS); v {
+	case "23.976", "23.98":
+		return timecode.FPS23976fps, nil
+	case "29.97":
+		return timecode.FPS2997, nil
+	case "59.94":
+		return 2 * timecode.FPS2997, nil
+	case "":
+	default:
+		fps, err := strconv.ParseFloat(v, cBits64)
+		if err != nil || fps <= 0 {
+			return 0, errors.Wrapf(timecode.ErrInvalidFPS, "FPS %q", v)
+		}
+		return fps, nil
+	}
+	switch v := a.heading(cVideoFormat); v {
+	case "NTSC":
+		return timecode.FPS2997, nil
+	case "PAL":
+		return 25, nil
+	default:
+		return 0, errors.Wrapf(timecode.ErrInvalidFPS, "no FPS and VIDEO_FORMAT %q", v)
+	}
+}
+
+// heading returns the value of the heading field `key`.
+func (a *ALE) heading(key string) string {
+	for _, f := range a.Heading {
+		if f.Key == key {
+			return f.Value
+		}
+	}
+	return ""
+}
+
+// column returns the index of the column `name` or -1.
+func (a *ALE) column(name string) int {
+	for i, c := range a.Columns {
+		if c == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// setCell sets the cell of the column `name` of `cells` to the timecode `tc` if any.  It appends empty
+// cells if needed.
+func (a *ALE) setCell(cells []string, name string, tc *timecode.Timecode) []string {
+	i := a.column(name)
+	if i < 0 || tc == nil {
+		return cells
+	}
+	for len(cells) <= i {
+		cells = append(cells, "")
+	}
+	cells[i] = tc.String()
+	return cells
+}
@@ -0,0 +1,114 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package ale
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wunderbarb/timecode"
+)
+
+var testCounter int
+
+func TestRead(t *testing.T) {
+	require, assert := Describe(t)
+
+	data, err := os.ReadFile("testdata/dailies.ale")
+	require.NoError(err)
+	a, err := Read(bytes.NewReader(data))
+	require.NoError(err)
+	assert.False(a.CRLF)
+	assert.Equal(timecode.Rate23976, a.Rate())
+	assert.Equal(Field{Key: "AUDIO_FORMAT", Value: "48khz"}, a.Heading[2])
+	require.Len(a.Rows, 3)
+	row := a.Rows[0]
+	assert.Equal("A001C003", a.Get(row, "Name"))
+	assert.Equal("good take", a.Get(row, "Custom Note"))
+	assert.Equal("", a.Get(row, "Unknown"))
+	assert.Equal("01:00:00:00", row.Start.String())
+	assert.Equal("01:00:10:00", row.End.String())
+	assert.Equal(240, row.Duration.Frame())
+	assert.Equal(timecode.Rate23976, row.Start.Rate())
+	assert.Nil(a.Rows[2].Duration)
+	assert.Equal("", a.Get(a.Rows[2], "Custom Note"))
+	assert.NoError(a.Validate())
+	assert.Equal(string(data), a.String())
+
+	data, err = os.ReadFile("testdata/df.ale")
+	require.NoError(err)
+	a, err = Read(bytes.NewReader(data))
+	require.NoError(err)
+	assert.True(a.CRLF)
+	assert.Equal(timecode.Rate2997DF, a.Rate())
+	assert.Equal(6, a.Rows[0].Duration.Frame())
+	assert.NoError(a.Validate())
+	var buf bytes.Buffer
+	require.NoError(a.Write(&buf))
+	assert.Equal(string(data), buf.String())
+}
+
+func TestRead_Errors(t *testing.T) {
+	_, assert := Describe(t)
+
+	tests := []struct {
+		src    string
+		expErr error
+	}{
+		{"Heading\nFIELD_DELIM\tCOMMAS\n", ErrInvalidLine},
+		{"Name\tStart\n", ErrInvalidLine},
+		{"Heading\nFPS\t25\nColumn\nName\nColumn\nStart\n", ErrInvalidLine},
+		{"Heading\nFPS\t25\nColumn\nName\nData\nA\tB\n", ErrInvalidLine},
+		{"Heading\nFPS\tfast\n", timecode.ErrInvalidFPS},
+		{"Heading\nVIDEO_FORMAT\t1080\n", timecode.ErrInvalidFPS},
+		{"Heading\nFPS\t25\nColumn\nStart\nData\n01:00:00;00\n", timecode.ErrInvalidFPS},
+		{"Heading\nFPS\t25\nColumn\nStart\nData\n01:00:00:25\n", timecode.ErrInvalidTimeCode},
+	}
+	for i, tt := range tests {
+		_, err := Read(strings.NewReader(tt.src))
+		assert.ErrorIs(err, tt.expErr, "sample %d", i+1)
+	}
+}
+
+func TestALE_Validate(t *testing.T) {
+	require, assert := Describe(t)
+
+	src := "Heading\nFIELD_DELIM\tTABS\nVIDEO_FORMAT\tPAL\n\nColumn\nName\tStart\tEnd\tDuration\n\nData\n" +
+		"A\t01:00:00:00\t01:00:01:00\t00:00:01:01\n"
+	a, err := Read(strings.NewReader(src))
+	require.NoError(err)
+	assert.Equal(timecode.Rate25, a.Rate())
+	assert.ErrorIs(a.Validate(), ErrDuration)
+
+	d, _ := timecode.NewFromRate(timecode.Rate25, 25)
+	a.Rows[0].Duration = d
+	assert.NoError(a.Validate())
+	assert.Contains(a.String(), "A\t01:00:00:00\t01:00:01:00\t00:00:01:00\n")
+
+	a.Rows = append(a.Rows, &Row{Cells: []string{"B"}, Start: a.Rows[0].Start})
+	assert.Contains(a.String(), "B\t01:00:00:00\n")
+}
+
+// Describe displays the rank of the test, the name of the function
+// and its optional description provided by 'msg'.  It initializes an assert
+// and a require function and returns them.
+func Describe(t *testing.T, msg ...string) (*require.Assertions,
+	*assert.Assertions) {
+
+	dispMsg := ""
+	if len(msg) != 0 {
+		dispMsg = msg[0]
+	}
+	name := strings.TrimPrefix(strings.TrimPrefix(t.Name(), "Test"), "_")
+	fmt.Printf("Test %d: %s %s\n", testCounter, name, dispMsg)
+	testCounter++
+	return require.New(t), assert.New(t)
+}
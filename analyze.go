@@ -0,0 +1,194 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"fmt"
+)
+
+// AnomalyKind is the kind of anomaly detected in a sequence of timecodes.
+type AnomalyKind int
+
+const (
+	// AnomalyGap indicates that frames are missing between two consecutive timecodes.
+	AnomalyGap AnomalyKind = iota
+	// AnomalyRepeat indicates that a timecode is repeated.
+	AnomalyRepeat
+	// AnomalyBackward indicates that a timecode is before the previous one.
+	AnomalyBackward
+	// AnomalyRollover indicates that the sequence crosses midnight.  It does not break the continuity.
+	AnomalyRollover
+	// AnomalyDroppedLabel indicates a drop frame timecode using a label that drop frame skips,
+	// e.g., 00:01:00;00.
+	AnomalyDroppedLabel
+	// AnomalyInvalid indicates a timecode that cannot be parsed.
+	AnomalyInvalid
+	// AnomalyRateChange indicates that the frame rate or the drop frame changes within the sequence.
+	AnomalyRateChange
+)
+
+// String returns the name of the anomaly kind.
+func (k AnomalyKind) String() string {
+	switch k {
+	case AnomalyGap:
+		return "gap"
+	case AnomalyRepeat:
+		return "repeat"
+	case AnomalyBackward:
+		return "backward"
+	case AnomalyRollover:
+		return "rollover"
+	case AnomalyDroppedLabel:
+		return "dropped label"
+	case AnomalyInvalid:
+		return "invalid"
+	case AnomalyRateChange:
+		return "rate change"
+	default:
+		return fmt.Sprintf("AnomalyKind(%d)", int(k))
+	}
+}
+
+// Anomaly describes a break in the continuity of a sequence of timecodes.
+type Anomaly struct {
+	Kind AnomalyKind
+	// Index is the index of the offending timecode in the sequence.
+	Index int
+	// Previous is the index of the last valid timecode it was compared to.  It is -1 if there is none.
+	Previous int
+	// Timecode is the offending timecode.
+	Timecode string
+	// Delta is the difference, in frames, between the actual position and the expected position.
+	// For a gap, it is the number of missing frames.  For a repeat of the previous timecode, it is -1.
+	Delta int
+}
+
+// Report is the result of the analysis of a sequence of timecodes.
+type Report struct {
+	// Count is the number of timecodes in the sequence.
+	Count int
+	// Anomalies lists the anomalies in the order of the sequence.
+	Anomalies []Anomaly
+}
+
+// Continuous returns true if the sequence has no anomaly other than midnight rollovers.
+func (r Report) Continuous() bool {
+	for _, a := range r.Anomalies {
+		if a.Kind != AnomalyRollover {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the anomalies of the given kind.
+func (r Report) Filter(kind AnomalyKind) []Anomaly {
+	var res []Anomaly
+	for _, a := range r.Anomalies {
+		if a.Kind == kind {
+			res = append(res, a)
+		}
+	}
+	return res
+}
+
+// Analyze verifies that the sequence of timecodes `tcs` is continuous, i.e., each timecode is at offset 1
+// from the previous one.  It reports the gaps, repeats, backward jumps, midnight rollovers and rate changes.
+func Analyze(tcs []Timecode) Report {
+	a := analyzer{previous: -1}
+	for i := range tcs {
+		a.next(i, &tcs[i])
+	}
+	return Report{Count: len(tcs), Anomalies: a.anomalies}
+}
+
+// AnalyzeStrings verifies that the sequence of timecode strings `ts` is continuous for the frame rate `fps`.
+// In addition to the anomalies reported by Analyze, it reports the strings that cannot be parsed and,
+// with drop frame, the labels that drop frame skips.
+func AnalyzeStrings(fps float64, dropFrame bool, ts []string) (Report, error) {
+	if fps <= 0.0 || (dropFrame && fps != FPS2997) {
+		return Report{}, ErrInvalidFPS
+	}
+	a := analyzer{previous: -1}
+	for i, s := range ts {
+		tc := &Timecode{fps: fps, dropFrame: dropFrame}
+		if err := tc.Parse(s); err != nil {
+			kind := AnomalyInvalid
+			if dropFrame && isDroppedLabel(s) {
+				kind = AnomalyDroppedLabel
+			}
+			a.add(kind, i, s, 0)
+			continue
+		}
+		a.next(i, tc)
+	}
+	return Report{Count: len(ts), Anomalies: a.anomalies}, nil
+}
+
+// analyzer holds the state of an analysis.
+type analyzer struct {
+	anomalies []Anomaly
+	last      *Timecode
+	previous  int
+}
+
+// next compares the timecode at index `i` with the last valid one.
+func (a *analyzer) next(i int, tc *Timecode) {
+	defer func() {
+		a.last = tc
+		a.previous = i
+	}()
+	if a.last == nil {
+		return
+	}
+	gap := i - a.previous
+	if tc.AtOffsetFrom(*a.last, gap) {
+		return
+	}
+	if !tc.sameFrameRate(*a.last) {
+		a.add(AnomalyRateChange, i, tc.String(), 0)
+		return
+	}
+	delta := a.last.FrameCount(*tc)
+	if delta < 0 {
+		day := tc.framesPerDay()
+		if delta+day < day/2 {
+			// Forward across midnight.
+			a.add(AnomalyRollover, i, tc.String(), 0)
+			delta += day
+		}
+	}
+	switch {
+	case delta == gap:
+	case delta > gap:
+		a.add(AnomalyGap, i, tc.String(), delta-gap)
+	case delta == 0:
+		a.add(AnomalyRepeat, i, tc.String(), -gap)
+	default:
+		a.add(AnomalyBackward, i, tc.String(), delta-gap)
+	}
+}
+
+func (a *analyzer) add(kind AnomalyKind, i int, ts string, delta int) {
+	a.anomalies = append(a.anomalies, Anomaly{Kind: kind, Index: i, Previous: a.previous, Timecode: ts,
+		Delta: delta})
+}
+
+// isDroppedLabel returns true if the well-formed timecode string `ts` is a label skipped by drop frame.
+func isDroppedLabel(ts string) bool {
+	if !_reTimecode.MatchString(ts) {
+		return false
+	}
+	const (
+		cMin   = 3
+		cSec   = 6
+		cFrame = 9
+	)
+	tsa := []rune(ts)
+	m1 := extractMin(tsa[cMin], tsa[cMin+1])
+	s1 := extractMin(tsa[cSec], tsa[cSec+1])
+	f := extractMin(tsa[cFrame], tsa[cFrame+1])
+	return isDropped(m1, s1, f)
+}
@@ -0,0 +1,76 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"testing"
+)
+
+func TestAnalyze(t *testing.T) {
+	_, assert := Describe(t)
+
+	tcs := make([]Timecode, 0, 10)
+	for _, fr := range []int{10, 11, 12, 12, 15, 14, 15, 16} {
+		tc, _ := NewFromFrame(cFPS25, fr)
+		tcs = append(tcs, *tc)
+	}
+	r := Analyze(tcs)
+	assert.Equal(8, r.Count)
+	assert.False(r.Continuous())
+	assert.Equal([]Anomaly{
+		{Kind: AnomalyRepeat, Index: 3, Previous: 2, Timecode: "00:00:00:12", Delta: -1},
+		{Kind: AnomalyGap, Index: 4, Previous: 3, Timecode: "00:00:00:15", Delta: 2},
+		{Kind: AnomalyBackward, Index: 5, Previous: 4, Timecode: "00:00:00:14", Delta: -2},
+	}, r.Anomalies)
+
+	t1, _ := NewFromFrame(cFPS25, 10)
+	t2, _ := NewFromFrame(cFPS24, 11)
+	r = Analyze([]Timecode{*t1, *t2})
+	assert.Len(r.Filter(AnomalyRateChange), 1)
+
+	t3, _ := NewFromString(cFPS25, "23:59:59:24")
+	t4, _ := NewFromString(cFPS25, "00:00:00:00")
+	r = Analyze([]Timecode{*t3, *t4})
+	assert.True(r.Continuous())
+	assert.Len(r.Filter(AnomalyRollover), 1)
+	assert.True(Analyze(nil).Continuous())
+}
+
+func TestAnalyzeStrings(t *testing.T) {
+	require, assert := Describe(t)
+
+	tests := []struct {
+		ts      []string
+		df      bool
+		expKind []AnomalyKind
+		expIdx  []int
+	}{
+		{[]string{"00:00:59:23", "00:01:00:00", "00:01:00:01"}, false, nil, nil},
+		{[]string{"00:00:59;29", "00:01:00;02", "00:01:00;03"}, true, nil, nil},
+		{[]string{"00:00:59;29", "00:01:00;00", "00:01:00;04"}, true,
+			[]AnomalyKind{AnomalyDroppedLabel, AnomalyGap}, []int{1, 2}},
+		{[]string{"00:00:00:01", "bad", "00:00:00:03"}, false, []AnomalyKind{AnomalyInvalid}, []int{1}},
+		{[]string{"23:59:59;29", "00:00:00;00"}, true, []AnomalyKind{AnomalyRollover}, []int{1}},
+		{[]string{"23:59:59:23", "00:00:00:01"}, false, []AnomalyKind{AnomalyRollover, AnomalyGap},
+			[]int{1, 1}},
+		{[]string{"01:00:00:00", "00:59:59:22"}, false, []AnomalyKind{AnomalyBackward}, []int{1}},
+	}
+	for i, tt := range tests {
+		fps := cFPS24
+		if tt.df {
+			fps = FPS2997
+		}
+		r, err := AnalyzeStrings(fps, tt.df, tt.ts)
+		require.NoError(err, "sample %d", i+1)
+		require.Len(r.Anomalies, len(tt.expKind), "sample %d", i+1)
+		for j, a := range r.Anomalies {
+			assert.Equal(tt.expKind[j], a.Kind, "sample %d", i+1)
+			assert.Equal(tt.expIdx[j], a.Index, "sample %d", i+1)
+		}
+	}
+	_, err := AnalyzeStrings(cFPS25, true, nil)
+	assert.ErrorIs(err, ErrInvalidFPS)
+	assert.Equal("dropped label", AnomalyDroppedLabel.String())
+}
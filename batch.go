@@ -0,0 +1,111 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// cMinChunk is the minimal number of items processed by a goroutine of the concurrent batch functions.
+const cMinChunk = 4096
+
+// FormatFrames formats the frames `frames` at the rate `r` as HH:MM:SS:ff or HH:MM:SS;ff.  The label of
+// frames[i] is written to dst[i], reusing its capacity.  `dst` is grown to the length of `frames` if
+// needed and returned.  The frame rate setup is done once for the whole slice.
+//
+// The frames must be positive.
+func FormatFrames(r Rate, frames []int, dst [][]byte) ([][]byte, error) {
+	return FormatFramesConcurrent(r, frames, dst, 1)
+}
+
+// FormatFramesConcurrent is like FormatFrames but splits `frames` among at most `workers` goroutines.
+// If `workers` is not positive, it uses GOMAXPROCS goroutines.  Each goroutine handles at least 4096
+// frames, so small slices are formatted by the calling goroutine.
+func FormatFramesConcurrent(r Rate, frames []int, dst [][]byte, workers int) ([][]byte, error) {
+	if !r.Valid() {
+		return nil, ErrInvalidFPS
+	}
+	for i, f := range frames {
+		if f < 0 {
+			return nil, errors.Wrapf(ErrInvalidFPS, "frame %d", i)
+		}
+	}
+	if cap(dst) < len(frames) {
+		dst = append(dst[:cap(dst)], make([][]byte, len(frames)-cap(dst))...)
+	}
+	dst = dst[:len(frames)]
+	ft := newFrameTable(r)
+	fanOut(len(frames), workers, func(lo int, hi int) {
+		for i := lo; i < hi; i++ {
+			dst[i] = ft.appendFormat(dst[i][:0], frames[i])
+		}
+	})
+	return dst, nil
+}
+
+// ParseAll parses the timecode strings `ts` at the rate `r` as Parse does and returns their frames.
+// errs[i] is the error of ts[i], usually a *ParseError, and frames[i] is 0 when it fails.  `errs` is nil
+// if all the strings are valid.  The frame rate setup is done once for the whole slice.
+func ParseAll(r Rate, ts []string) (frames []int, errs []error) {
+	return ParseAllConcurrent(r, ts, 1)
+}
+
+// ParseAllConcurrent is like ParseAll but splits `ts` among at most `workers` goroutines.  If
+// `workers` is not positive, it uses GOMAXPROCS goroutines.  Each goroutine handles at least 4096
+// strings, so small slices are parsed by the calling goroutine.
+func ParseAllConcurrent(r Rate, ts []string, workers int) (frames []int, errs []error) {
+	frames = make([]int, len(ts))
+	errs = make([]error, len(ts))
+	if !r.Valid() {
+		for i := range errs {
+			errs[i] = ErrInvalidFPS
+		}
+		return frames, errs
+	}
+	ft := newFrameTable(r)
+	fanOut(len(ts), workers, func(lo int, hi int) {
+		for i := lo; i < hi; i++ {
+			frames[i], errs[i] = parseTimecode(ft, ts[i])
+		}
+	})
+	for _, err := range errs {
+		if err != nil {
+			return frames, errs
+		}
+	}
+	return frames, nil
+}
+
+// fanOut calls `fn` on consecutive ranges [lo, hi) covering [0, n) from at most `workers` goroutines
+// and waits for their completion.
+func fanOut(n int, workers int, fn func(lo int, hi int)) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if maxWorkers := n / cMinChunk; workers > maxWorkers {
+		workers = maxWorkers
+	}
+	if workers <= 1 {
+		fn(0, n)
+		return
+	}
+	chunk := (n + workers - 1) / workers
+	var wg sync.WaitGroup
+	for lo := 0; lo < n; lo += chunk {
+		hi := lo + chunk
+		if hi > n {
+			hi = n
+		}
+		wg.Add(1)
+		go func(lo int, hi int) {
+			defer wg.Done()
+			fn(lo, hi)
+		}(lo, hi)
+	}
+	wg.Wait()
+}
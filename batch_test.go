@@ -0,0 +1,101 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"testing"
+)
+
+func TestFormatFrames(t *testing.T) {
+	require, assert := Describe(t)
+
+	dst, err := FormatFrames(Rate2997DF, []int{0, 1800, 17982}, nil)
+	require.NoError(err)
+	require.Len(dst, 3)
+	assert.Equal("00:00:00;00", string(dst[0]))
+	assert.Equal("00:01:00;02", string(dst[1]))
+	assert.Equal("00:10:00;00", string(dst[2]))
+
+	buf := make([]byte, 0, 16)
+	dst, err = FormatFrames(Rate25, []int{25}, [][]byte{buf, nil})
+	require.NoError(err)
+	require.Len(dst, 1)
+	assert.Equal("00:00:01:00", string(dst[0]))
+	assert.Same(&buf[:1][0], &dst[0][0])
+
+	_, err = FormatFrames(Rate{FPS: cFPS25, DropFrame: true}, []int{1}, nil)
+	assert.ErrorIs(err, ErrInvalidFPS)
+	_, err = FormatFrames(Rate25, []int{1, -1}, nil)
+	assert.ErrorIs(err, ErrInvalidFPS)
+}
+
+func TestParseAll(t *testing.T) {
+	require, assert := Describe(t)
+
+	frames, errs := ParseAll(Rate2997DF, []string{"00:00:00;00", "00:01:00;02", "00:10:00;00"})
+	require.Nil(errs)
+	assert.Equal([]int{0, 1800, 17982}, frames)
+
+	frames, errs = ParseAll(Rate25, []string{"00:00:01:00", "00:00:01:25", "bad"})
+	require.Len(errs, 3)
+	assert.Equal([]int{25, 0, 0}, frames)
+	assert.NoError(errs[0])
+	assert.ErrorIs(errs[1], ErrInconsistentFPS)
+	var pe *ParseError
+	assert.ErrorAs(errs[2], &pe)
+
+	_, errs = ParseAll(Rate{}, []string{"00:00:01:00"})
+	require.Len(errs, 1)
+	assert.ErrorIs(errs[0], ErrInvalidFPS)
+}
+
+func TestConcurrent(t *testing.T) {
+	require, assert := Describe(t)
+
+	const cN = 5*cMinChunk + 17
+	frames := make([]int, cN)
+	for i := range frames {
+		frames[i] = i * 7
+	}
+	for _, workers := range []int{0, 1, 3, 64} {
+		dst, err := FormatFramesConcurrent(Rate2997DF, frames, nil, workers)
+		require.NoError(err, "workers %d", workers)
+		ts := make([]string, len(dst))
+		for i, b := range dst {
+			ts[i] = string(b)
+		}
+		tc, _ := NewFromRate(Rate2997DF, frames[cN-1])
+		assert.Equal(tc.String(), ts[cN-1], "workers %d", workers)
+		got, errs := ParseAllConcurrent(Rate2997DF, ts, workers)
+		require.Nil(errs, "workers %d", workers)
+		assert.Equal(frames, got, "workers %d", workers)
+	}
+}
+
+func BenchmarkFormatFrames(b *testing.B) {
+	frames := make([]int, 100000)
+	for i := range frames {
+		frames[i] = i
+	}
+	dst, _ := FormatFrames(Rate2997DF, frames, nil)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dst, _ = FormatFrames(Rate2997DF, frames, dst)
+	}
+}
+
+func BenchmarkFormatFramesConcurrent(b *testing.B) {
+	frames := make([]int, 100000)
+	for i := range frames {
+		frames[i] = i
+	}
+	dst, _ := FormatFrames(Rate2997DF, frames, nil)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dst, _ = FormatFramesConcurrent(Rate2997DF, frames, dst, 0)
+	}
+}
@@ -0,0 +1,91 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package bwf
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+)
+
+// The offsets of the fields of the bext chunk as defined by EBU Tech 3285.
+const (
+	cOffsetDescription         = 0
+	cOffsetOriginator          = 256
+	cOffsetOriginatorReference = 288
+	cOffsetOriginationDate     = 320
+	cOffsetOriginationTime     = 330
+	cOffsetTimeReference       = 338
+	cOffsetVersion             = 346
+	cBextSize                  = 602
+)
+
+// Bext is the Broadcast Audio Extension chunk.  The UMID, loudness and reserved fields are preserved as
+// they are.
+type Bext struct {
+	Description         string
+	Originator          string
+	OriginatorReference string
+	// OriginationDate is formatted as `yyyy-mm-dd`.
+	OriginationDate string
+	// OriginationTime is formatted as `hh:mm:ss`.
+	OriginationTime string
+	// TimeReference is the number of samples since midnight of the first sample.
+	TimeReference uint64
+	Version       uint16
+	CodingHistory string
+	raw           []byte
+}
+
+// ParseBext parses the body of a bext chunk.
+func ParseBext(b []byte) (*Bext, error) {
+	if len(b) < cBextSize {
+		return nil, errors.Wrapf(ErrInvalidChunk, "bext of %d bytes", len(b))
+	}
+	le := binary.LittleEndian
+	return &Bext{
+		Description:         cString(b[cOffsetDescription:cOffsetOriginator]),
+		Originator:          cString(b[cOffsetOriginator:cOffsetOriginatorReference]),
+		OriginatorReference: cString(b[cOffsetOriginatorReference:cOffsetOriginationDate]),
+		OriginationDate:     cString(b[cOffsetOriginationDate:cOffsetOriginationTime]),
+		OriginationTime:     cString(b[cOffsetOriginationTime:cOffsetTimeReference]),
+		TimeReference: uint64(le.Uint32(b[cOffsetTimeReference:])) |
+			uint64(le.Uint32(b[cOffsetTimeReference+4:]))<<32,
+		Version:       le.Uint16(b[cOffsetVersion:]),
+		CodingHistory: cString(b[cBextSize:]),
+		raw:           append([]byte(nil), b[:cBextSize]...),
+	}, nil
+}
+
+// Bytes returns the body of the bext chunk.  The strings longer than their field are truncated.
+func (x *Bext) Bytes() []byte {
+	b := make([]byte, cBextSize, cBextSize+len(x.CodingHistory))
+	copy(b, x.raw)
+	putString(b[cOffsetDescription:cOffsetOriginator], x.Description)
+	putString(b[cOffsetOriginator:cOffsetOriginatorReference], x.Originator)
+	putString(b[cOffsetOriginatorReference:cOffsetOriginationDate], x.OriginatorReference)
+	putString(b[cOffsetOriginationDate:cOffsetOriginationTime], x.OriginationDate)
+	putString(b[cOffsetOriginationTime:cOffsetTimeReference], x.OriginationTime)
+	le := binary.LittleEndian
+	le.PutUint32(b[cOffsetTimeReference:], uint32(x.TimeReference))
+	le.PutUint32(b[cOffsetTimeReference+4:], uint32(x.TimeReference>>32))
+	le.PutUint16(b[cOffsetVersion:], x.Version)
+	return append(b, x.CodingHistory...)
+}
+
+// cString returns the ASCII string of `b` up to the first NUL.
+func cString(b []byte) string {
+	if i := bytes.IndexByte(b, 0); i >= 0 {
+		b = b[:i]
+	}
+	return string(b)
+}
+
+// putString writes `s` to the field `dst` and pads it with NUL.
+func putString(dst []byte, s string) {
+	n := copy(dst, s)
+	clear(dst[n:])
+}
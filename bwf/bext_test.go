@@ -0,0 +1,41 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package bwf
+
+import (
+	"testing"
+)
+
+func TestParseBext(t *testing.T) {
+	require, assert := Describe(t)
+
+	x := &Bext{
+		Description:         "scene 1 take 2",
+		Originator:          "recorder",
+		OriginatorReference: "REF0001",
+		OriginationDate:     "2026-10-18",
+		OriginationTime:     "10:00:00",
+		TimeReference:       1<<32 + 5,
+		Version:             2,
+		CodingHistory:       "A=PCM,F=48000,W=24,M=stereo\r\n",
+	}
+	b := x.Bytes()
+	require.Len(b, cBextSize+len(x.CodingHistory))
+	b[cOffsetVersion+2] = 0x42 // UMID
+	x1, err := ParseBext(b)
+	require.NoError(err)
+	assert.Equal(x.Description, x1.Description)
+	assert.Equal(x.OriginationTime, x1.OriginationTime)
+	assert.Equal(x.TimeReference, x1.TimeReference)
+	assert.Equal(x.Version, x1.Version)
+	assert.Equal(x.CodingHistory, x1.CodingHistory)
+	assert.Equal(b, x1.Bytes())
+
+	x1.Description = "short"
+	assert.Equal("short", cString(x1.Bytes()[:cOffsetOriginator]))
+
+	_, err = ParseBext(b[:cBextSize-1])
+	assert.ErrorIs(err, ErrInvalidChunk)
+}
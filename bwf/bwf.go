@@ -0,0 +1,345 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+// Package bwf reads and writes the timecode metadata of Broadcast WAV files, i.e., the TimeReference of
+// the `bext` chunk and the timecode rate and flag of the `iXML` chunk.  It converts them from and to
+// timecode.Timecode to align audio with picture.
+//
+// The TimeReference is the number of samples since midnight of the first sample of the file.
+package bwf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/pkg/errors"
+
+	"github.com/wunderbarb/timecode"
+)
+
+var (
+	// ErrNotWAVE is returned when the file is not a RIFF WAVE file.  RF64 files are not supported.
+	ErrNotWAVE = errors.New("bwf: not a RIFF WAVE file")
+	// ErrInvalidChunk is returned when a chunk is truncated or malformed.
+	ErrInvalidChunk = errors.New("bwf: invalid chunk")
+	// ErrNoRate is returned when the file has no iXML timecode rate.
+	ErrNoRate = errors.New("bwf: no timecode rate")
+	// ErrNoSampleRate is returned when the file has no sample rate.
+	ErrNoSampleRate = errors.New("bwf: no sample rate")
+)
+
+const (
+	cRIFF       = "RIFF"
+	cWAVE       = "WAVE"
+	cFmt        = "fmt "
+	cBext       = "bext"
+	cIXML       = "iXML"
+	cData       = "data"
+	cHeaderSize = 8
+	cIDSize     = 4
+	cFmtSize    = 16
+	cOffsetRate = 4
+)
+
+// File is the timecode metadata of a Broadcast WAV file.
+type File struct {
+	// SampleRate is the sample rate of the `fmt ` chunk.
+	SampleRate int
+	// Bext is the `bext` chunk or nil if there is none.
+	Bext *Bext
+	// IXML is the `iXML` chunk or nil if there is none.
+	IXML *IXML
+}
+
+// Read reads the `fmt `, `bext` and `iXML` chunks of the WAV file read from `r`.  It skips the other chunks.
+// The chunks are read up to the end of the file whatever the RIFF size.  A truncated last chunk other than
+// `fmt `, `bext` and `iXML`, e.g., `data`, or a missing last pad byte ends the file.
+func Read(r io.Reader) (*File, error) {
+	if err := readRIFFHeader(r); err != nil {
+		return nil, err
+	}
+	f := &File{}
+	for {
+		id, size, err := readChunkHeader(r)
+		if err == io.EOF {
+			return f, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := f.readChunk(r, id, size); err == io.EOF {
+			return f, nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Rate returns the timecode rate declared by the iXML chunk.
+func (f *File) Rate() (timecode.Rate, error) {
+	if f.IXML == nil {
+		return timecode.Rate{}, ErrNoRate
+	}
+	return f.IXML.Rate()
+}
+
+// Timecode returns the timecode of the first sample of the file.  It uses the TimeReference of the bext
+// chunk or else the timestamp of the iXML chunk.
+func (f *File) Timecode() (*timecode.Timecode, error) {
+	r, err := f.Rate()
+	if err != nil {
+		return nil, err
+	}
+	sr := f.SampleRate
+	if sr == 0 {
+		sr = f.IXML.sampleRate()
+	}
+	if sr <= 0 {
+		return nil, ErrNoSampleRate
+	}
+	samples := f.IXML.samples()
+	if f.Bext != nil {
+		samples = f.Bext.TimeReference
+	}
+	return timecode.NewFromSamples(r, int64(samples), sr)
+}
+
+// SetTimecode sets the TimeReference of the bext chunk and the timecode rate, flag and timestamp of the
+// iXML chunk to the timecode `tc`.  It creates the chunks if needed.  The sample rate must be known.
+func (f *File) SetTimecode(tc timecode.Timecode) error {
+	if f.SampleRate <= 0 {
+		return ErrNoSampleRate
+	}
+	samples := uint64(tc.Samples(f.SampleRate))
+	if f.Bext == nil {
+		f.Bext = &Bext{Version: 1}
+	}
+	f.Bext.TimeReference = samples
+	if f.IXML == nil {
+		f.IXML = NewIXML()
+	}
+	f.IXML.setTimecode(tc.Rate(), samples, f.SampleRate)
+	return nil
+}
+
+// Write copies the WAV file `src` to `dst` with the bext and iXML chunks of `f`.  The existing chunks are
+// replaced in place and the missing ones are inserted before the `data` chunk.  The audio is streamed.
+func (f *File) Write(dst io.Writer, src io.ReadSeeker) error {
+	chunks, err := scanChunks(src)
+	if err != nil {
+		return err
+	}
+	chunks = f.replaceChunks(chunks)
+	var size int64 = cIDSize
+	for _, c := range chunks {
+		size += cHeaderSize + padded(c.size)
+	}
+	if size > math.MaxUint32 {
+		return errors.Wrapf(ErrInvalidChunk, "RIFF of %d bytes", size)
+	}
+	hdr := make([]byte, 0, cHeaderSize+cIDSize)
+	hdr = append(hdr, cRIFF...)
+	hdr = binary.LittleEndian.AppendUint32(hdr, uint32(size))
+	hdr = append(hdr, cWAVE...)
+	if _, err := dst.Write(hdr); err != nil {
+		return err
+	}
+	for _, c := range chunks {
+		if err := c.write(dst, src); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// chunk is a chunk to write.  Its body is `body` or else the `size` bytes at the offset `offset` of the
+// source.
+type chunk struct {
+	id     string
+	size   uint32
+	offset int64
+	body   []byte
+}
+
+// scanChunks returns the chunks of the WAV file `src`.  A truncated chunk other than `fmt `, `bext` and
+// `iXML` is shortened to the end of the file.
+func scanChunks(src io.ReadSeeker) ([]chunk, error) {
+	end, err := src.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if err := readRIFFHeader(src); err != nil {
+		return nil, err
+	}
+	var chunks []chunk
+	for {
+		id, size, err := readChunkHeader(src)
+		if err == io.EOF {
+			return chunks, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		offset, err := src.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, err
+		}
+		if left := end - offset; int64(size) > left {
+			if isParsed(id) {
+				return nil, errors.Wrapf(ErrInvalidChunk, "%q of %d bytes instead of %d", id, left, size)
+			}
+			size = uint32(left)
+		}
+		if _, err := src.Seek(padded(size), io.SeekCurrent); err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, chunk{id: id, size: size, offset: offset})
+	}
+}
+
+// replaceChunks returns the chunks `chunks` with the bext and iXML chunks of `f`.
+func (f *File) replaceChunks(chunks []chunk) []chunk {
+	var added []chunk
+	for _, c := range f.chunks() {
+		if i := indexChunk(chunks, c.id); i >= 0 {
+			chunks[i] = c
+			continue
+		}
+		added = append(added, c)
+	}
+	i := indexChunk(chunks, cData)
+	if i < 0 {
+		i = len(chunks)
+	}
+	return append(chunks[:i:i], append(added, chunks[i:]...)...)
+}
+
+// chunks returns the bext and iXML chunks of `f`.
+func (f *File) chunks() []chunk {
+	var chunks []chunk
+	if f.Bext != nil {
+		b := f.Bext.Bytes()
+		chunks = append(chunks, chunk{id: cBext, size: uint32(len(b)), body: b})
+	}
+	if f.IXML != nil {
+		b := f.IXML.Bytes()
+		chunks = append(chunks, chunk{id: cIXML, size: uint32(len(b)), body: b})
+	}
+	return chunks
+}
+
+// indexChunk returns the index of the first chunk `id` of `chunks` or -1.
+func indexChunk(chunks []chunk, id string) int {
+	for i, c := range chunks {
+		if c.id == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// write writes the chunk to `dst`.  It copies the body from `src` if the chunk has no body.
+func (c chunk) write(dst io.Writer, src io.ReadSeeker) error {
+	hdr := make([]byte, 0, cHeaderSize)
+	hdr = append(hdr, c.id...)
+	hdr = binary.LittleEndian.AppendUint32(hdr, c.size)
+	if _, err := dst.Write(hdr); err != nil {
+		return err
+	}
+	if c.body == nil {
+		if _, err := src.Seek(c.offset, io.SeekStart); err != nil {
+			return err
+		}
+		if _, err := io.CopyN(dst, src, int64(c.size)); err != nil {
+			return errors.Wrapf(ErrInvalidChunk, "%q: %v", c.id, err)
+		}
+	} else if _, err := dst.Write(c.body); err != nil {
+		return err
+	}
+	// The pad byte is written even if the source misses it.
+	if c.size%2 == 1 {
+		_, err := dst.Write([]byte{0})
+		return err
+	}
+	return nil
+}
+
+// readChunk reads the body of the chunk `id` of size `size` and its pad byte.  It returns io.EOF if a
+// skipped chunk is truncated or if the pad byte is missing at the end of the file.
+func (f *File) readChunk(r io.Reader, id string, size uint32) error {
+	if !isParsed(id) {
+		if _, err := io.CopyN(io.Discard, r, padded(size)); err != nil {
+			if err == io.EOF {
+				return io.EOF
+			}
+			return errors.Wrapf(ErrInvalidChunk, "%q: %v", id, err)
+		}
+		return nil
+	}
+	// The buffer grows with the bytes actually read rather than with the declared size.
+	b, err := io.ReadAll(io.LimitReader(r, int64(size)))
+	if err != nil {
+		return err
+	}
+	if int64(len(b)) < int64(size) {
+		return errors.Wrapf(ErrInvalidChunk, "%q of %d bytes instead of %d", id, len(b), size)
+	}
+	if size%2 == 1 {
+		var pad [1]byte
+		if _, err := io.ReadFull(r, pad[:]); err != nil && err != io.EOF {
+			return err
+		}
+	}
+	switch id {
+	case cFmt:
+		if len(b) < cFmtSize {
+			return errors.Wrapf(ErrInvalidChunk, "%q of %d bytes", id, len(b))
+		}
+		f.SampleRate = int(binary.LittleEndian.Uint32(b[cOffsetRate:]))
+	case cBext:
+		f.Bext, err = ParseBext(b)
+	case cIXML:
+		f.IXML, err = ParseIXML(b)
+	}
+	return err
+}
+
+// readRIFFHeader reads the RIFF header.  The RIFF size is ignored as recorders often get it wrong.
+func readRIFFHeader(r io.Reader) error {
+	var hdr [cHeaderSize + cIDSize]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return errors.Wrapf(ErrNotWAVE, "%v", err)
+	}
+	if !bytes.Equal(hdr[:cIDSize], []byte(cRIFF)) || !bytes.Equal(hdr[cHeaderSize:], []byte(cWAVE)) {
+		return ErrNotWAVE
+	}
+	return nil
+}
+
+// readChunkHeader reads the header of the next chunk.  It returns io.EOF at the end of the file,
+// including after a truncated header.
+func readChunkHeader(r io.Reader) (string, uint32, error) {
+	var hdr [cHeaderSize]byte
+	if _, err := io.ReadFull(r, hdr[:]); err == io.EOF || err == io.ErrUnexpectedEOF {
+		return "", 0, io.EOF
+	} else if err != nil {
+		return "", 0, err
+	}
+	return string(hdr[:cIDSize]), binary.LittleEndian.Uint32(hdr[cIDSize:]), nil
+}
+
+// isParsed returns true if the chunk `id` is parsed rather than skipped.
+func isParsed(id string) bool {
+	return id == cFmt || id == cBext || id == cIXML
+}
+
+// padded returns the size of a chunk body of `size` bytes with its pad byte.
+func padded(size uint32) int64 {
+	return int64(size) + int64(size%2)
+}
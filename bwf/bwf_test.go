@@ -0,0 +1,191 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package bwf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wunderbarb/timecode"
+)
+
+var testCounter int
+
+const _ixml24 = `<?xml version="1.0" encoding="UTF-8"?>
+<BWFXML>
+<IXML_VERSION>1.5</IXML_VERSION>
+<SPEED>
+<TIMECODE_RATE>24/1</TIMECODE_RATE>
+<TIMECODE_FLAG>NDF</TIMECODE_FLAG>
+</SPEED>
+</BWFXML>
+`
+
+func TestRead(t *testing.T) {
+	require, assert := Describe(t)
+
+	b := newBext(t, 172800000)
+	wav := newWAV(48000, chunk{id: cBext, body: b}, chunk{id: cIXML, body: []byte(_ixml24)},
+		chunk{id: cData, body: make([]byte, 12)})
+	f, err := Read(bytes.NewReader(wav))
+	require.NoError(err)
+	assert.Equal(48000, f.SampleRate)
+	assert.Equal(uint64(172800000), f.Bext.TimeReference)
+	r, err := f.Rate()
+	require.NoError(err)
+	assert.Equal(timecode.Rate24, r)
+	tc, err := f.Timecode()
+	require.NoError(err)
+	assert.Equal("01:00:00:00", tc.String())
+
+	_, err = Read(strings.NewReader("RIFF\x04\x00\x00\x00AVI "))
+	assert.ErrorIs(err, ErrNotWAVE)
+	// The RIFF size is wrong and the data chunk is truncated.
+	binary.LittleEndian.PutUint32(wav[4:], 0)
+	f, err = Read(bytes.NewReader(wav[:len(wav)-4]))
+	require.NoError(err)
+	assert.Equal(uint64(172800000), f.Bext.TimeReference)
+	// The last odd iXML chunk misses its pad byte.
+	wav = newWAV(48000, chunk{id: cBext, body: b}, chunk{id: cIXML, body: []byte(_ixml24)})
+	f, err = Read(bytes.NewReader(wav[:len(wav)-1]))
+	require.NoError(err)
+	assert.NotNil(f.IXML)
+	// The parsed chunks overflow the file.
+	_, err = Read(bytes.NewReader(wav[:len(wav)-2]))
+	assert.ErrorIs(err, ErrInvalidChunk)
+	_, err = Read(strings.NewReader("RIFF\x00\x00\x00\x00WAVEfmt \xff\xff\xff\xff"))
+	assert.ErrorIs(err, ErrInvalidChunk)
+	_, err = Read(strings.NewReader("RIFF\xff\xff\xff\xffWAVEbext\x00\xff\xff\xff\x00\x00"))
+	assert.ErrorIs(err, ErrInvalidChunk)
+}
+
+func TestFile_Timecode(t *testing.T) {
+	require, assert := Describe(t)
+
+	ixml := strings.Replace(_ixml24, "<TIMECODE_RATE>24/1</TIMECODE_RATE>\n<TIMECODE_FLAG>NDF",
+		"<TIMECODE_RATE>30000/1001</TIMECODE_RATE>\n<TIMECODE_FLAG>DF", 1)
+	x, err := ParseIXML([]byte(ixml))
+	require.NoError(err)
+	x.setSpeed(cSamplesLo, "172799828")
+	x.setSpeed(cSampleRate, "48000")
+	f := &File{IXML: x}
+	tc, err := f.Timecode()
+	require.NoError(err)
+	assert.Equal("01:00:00;00", tc.String())
+
+	_, err = (&File{}).Timecode()
+	assert.ErrorIs(err, ErrNoRate)
+	_, err = (&File{IXML: NewIXML()}).Timecode()
+	assert.ErrorIs(err, ErrNoRate)
+	f.IXML.setSpeed(cSampleRate, "0")
+	_, err = f.Timecode()
+	assert.ErrorIs(err, ErrNoSampleRate)
+}
+
+func TestFile_Write(t *testing.T) {
+	require, assert := Describe(t)
+
+	audio := []byte("0123456789ab")
+	src := newWAV(48000, chunk{id: "LIST", body: []byte("odd")}, chunk{id: cData, body: audio})
+	f, err := Read(bytes.NewReader(src))
+	require.NoError(err)
+	assert.Nil(f.Bext)
+	tc, _ := timecode.NewFromRate(timecode.Rate2997DF, 0)
+	require.NoError(tc.Parse("10:00:00;00"))
+	require.NoError(f.SetTimecode(*tc))
+
+	var dst bytes.Buffer
+	require.NoError(f.Write(&dst, bytes.NewReader(src)))
+	out := dst.Bytes()
+	assert.Equal(uint32(len(out)-cHeaderSize), binary.LittleEndian.Uint32(out[cIDSize:]))
+	assert.True(bytes.HasSuffix(out, audio))
+	assert.Less(bytes.Index(out, []byte(cBext)), bytes.Index(out, []byte(cData)))
+	f1, err := Read(bytes.NewReader(out))
+	require.NoError(err)
+	tc1, err := f1.Timecode()
+	require.NoError(err)
+	assert.Equal("10:00:00;00", tc1.String())
+	assert.Equal("30000/1001", f1.IXML.Get(cTimecodeRate))
+	assert.Equal("DF", f1.IXML.Get(cTimecodeFlag))
+
+	// Replaces the chunks in place.
+	require.NoError(tc.Parse("10:00:00;02"))
+	require.NoError(f1.SetTimecode(*tc))
+	var dst2 bytes.Buffer
+	require.NoError(f1.Write(&dst2, bytes.NewReader(out)))
+	assert.Equal(1, bytes.Count(dst2.Bytes(), []byte(cBext)))
+	assert.Equal(1, bytes.Count(dst2.Bytes(), []byte(cIXML)))
+	f2, err := Read(bytes.NewReader(dst2.Bytes()))
+	require.NoError(err)
+	tc2, err := f2.Timecode()
+	require.NoError(err)
+	assert.Equal("10:00:00;02", tc2.String())
+
+	// The source misses the pad byte of its last chunk and has a wrong RIFF size.
+	src = newWAV(48000, chunk{id: cData, body: audio}, chunk{id: "LIST", body: []byte("odd")})
+	src = src[:len(src)-1]
+	binary.LittleEndian.PutUint32(src[cIDSize:], 0)
+	var dst3 bytes.Buffer
+	require.NoError(f.Write(&dst3, bytes.NewReader(src)))
+	out = dst3.Bytes()
+	assert.Equal(uint32(len(out)-cHeaderSize), binary.LittleEndian.Uint32(out[cIDSize:]))
+	assert.True(bytes.HasSuffix(out, []byte("odd\x00")))
+	_, err = Read(bytes.NewReader(out))
+	assert.NoError(err)
+
+	assert.ErrorIs((&File{}).SetTimecode(*tc), ErrNoSampleRate)
+	assert.ErrorIs(f.Write(&dst, strings.NewReader("WAVE")), ErrNotWAVE)
+	assert.ErrorIs(f.Write(&dst, strings.NewReader("RIFF\x00\x00\x00\x00WAVEfmt \xff\xff\xff\xff")),
+		ErrInvalidChunk)
+}
+
+// newWAV returns a WAV file with a `fmt ` chunk at the sample rate `sampleRate` followed by the chunks
+// `chunks`.
+func newWAV(sampleRate int, chunks ...chunk) []byte {
+	fmtChunk := make([]byte, cFmtSize)
+	binary.LittleEndian.PutUint16(fmtChunk, 1)
+	binary.LittleEndian.PutUint32(fmtChunk[cOffsetRate:], uint32(sampleRate))
+	chunks = append([]chunk{{id: cFmt, body: fmtChunk}}, chunks...)
+	var body []byte
+	for _, c := range chunks {
+		body = append(body, c.id...)
+		body = binary.LittleEndian.AppendUint32(body, uint32(len(c.body)))
+		body = append(body, c.body...)
+		if len(c.body)%2 == 1 {
+			body = append(body, 0)
+		}
+	}
+	wav := []byte(cRIFF)
+	wav = binary.LittleEndian.AppendUint32(wav, uint32(len(body)+cIDSize))
+	wav = append(wav, cWAVE...)
+	return append(wav, body...)
+}
+
+func newBext(t *testing.T, timeReference uint64) []byte {
+	b := (&Bext{Description: "scene 1 take 2", TimeReference: timeReference, Version: 1}).Bytes()
+	if len(b) != cBextSize {
+		t.Fatalf("bext of %d bytes", len(b))
+	}
+	return b
+}
+
+func Describe(t *testing.T, msg ...string) (*require.Assertions,
+	*assert.Assertions) {
+
+	dispMsg := ""
+	if len(msg) != 0 {
+		dispMsg = msg[0]
+	}
+	name := strings.TrimPrefix(strings.TrimPrefix(t.Name(), "Test"), "_")
+	fmt.Printf("Test %d: %s %s\n", testCounter, name, dispMsg)
+	testCounter++
+	return require.New(t), assert.New(t)
+}
@@ -0,0 +1,235 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package bwf
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/wunderbarb/timecode"
+)
+
+const (
+	cBWFXML         = "BWFXML"
+	cSpeed          = "SPEED"
+	cTimecodeRate   = "TIMECODE_RATE"
+	cTimecodeFlag   = "TIMECODE_FLAG"
+	cSamplesHi      = "TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_HI"
+	cSamplesLo      = "TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_LO"
+	cSampleRate     = "TIMESTAMP_SAMPLE_RATE"
+	cDF             = "DF"
+	cNDF            = "NDF"
+	cBase           = 10
+	cBits32         = 32
+	cBits64         = 64
+	cIXMLTemplate   = "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<BWFXML>\n<IXML_VERSION>1.5</IXML_VERSION>\n</BWFXML>\n"
+	cIXMLSpeedEmpty = "<SPEED>\n</SPEED>\n"
+	cSelfClosing    = "/>"
+)
+
+// IXML is the iXML chunk.  The document is kept as it is and only the timecode elements of `SPEED` are
+// edited.
+type IXML struct {
+	doc string
+}
+
+// NewIXML returns a minimal iXML document.
+func NewIXML() *IXML {
+	return &IXML{doc: cIXMLTemplate}
+}
+
+// ParseIXML parses the body of an iXML chunk.  The trailing NUL padding is removed.
+func ParseIXML(b []byte) (*IXML, error) {
+	b = bytes.TrimRight(b, "\x00")
+	if !bytes.Contains(b, []byte("<"+cBWFXML)) {
+		return nil, errors.Wrapf(ErrInvalidChunk, "iXML without %s", cBWFXML)
+	}
+	return &IXML{doc: string(b)}, nil
+}
+
+// Bytes returns the body of the iXML chunk.
+func (x *IXML) Bytes() []byte {
+	return []byte(x.doc)
+}
+
+// Get returns the trimmed text of the first element `tag` of the document, e.g., `PROJECT`, or an empty
+// string if there is none.
+func (x *IXML) Get(tag string) string {
+	return x.text(tag, 0, len(x.doc))
+}
+
+// Rate returns the timecode rate declared by `TIMECODE_RATE`, e.g., `30000/1001`, and `TIMECODE_FLAG` of
+// `SPEED`.  A rate close to an NTSC rate, e.g., `2997/100`, is snapped to it.
+func (x *IXML) Rate() (timecode.Rate, error) {
+	v := x.speed(cTimecodeRate)
+	if v == "" {
+		return timecode.Rate{}, ErrNoRate
+	}
+	n, d, found := strings.Cut(v, "/")
+	num, err := strconv.ParseFloat(n, cBits64)
+	den := 1.0
+	if err == nil && found {
+		den, err = strconv.ParseFloat(d, cBits64)
+	}
+	if err != nil || den <= 0 {
+		return timecode.Rate{}, errors.Wrapf(timecode.ErrInvalidFPS, "%s %q", cTimecodeRate, v)
+	}
+	r := timecode.Rate{FPS: timecode.SnapNTSC(num / den), DropFrame: x.speed(cTimecodeFlag) == cDF}
+	if !r.Valid() {
+		return timecode.Rate{}, errors.Wrapf(timecode.ErrInvalidFPS, "%s %q", cTimecodeRate, v)
+	}
+	return r, nil
+}
+
+// samples returns the timestamp in samples since midnight or 0 if there is none.
+func (x *IXML) samples() uint64 {
+	if x == nil {
+		return 0
+	}
+	hi, _ := strconv.ParseUint(x.speed(cSamplesHi), cBase, cBits32)
+	lo, _ := strconv.ParseUint(x.speed(cSamplesLo), cBase, cBits32)
+	return hi<<cBits32 | lo
+}
+
+// sampleRate returns the sample rate of the timestamp or 0 if there is none.
+func (x *IXML) sampleRate() int {
+	if x == nil {
+		return 0
+	}
+	sr, _ := strconv.Atoi(x.speed(cSampleRate))
+	return sr
+}
+
+// setTimecode sets the timecode elements of `SPEED`.
+func (x *IXML) setTimecode(r timecode.Rate, samples uint64, sampleRate int) {
+	flag := cNDF
+	if r.DropFrame {
+		flag = cDF
+	}
+	x.setSpeed(cTimecodeRate, formatRate(r))
+	x.setSpeed(cTimecodeFlag, flag)
+	x.setSpeed(cSamplesHi, strconv.FormatUint(samples>>cBits32, cBase))
+	x.setSpeed(cSamplesLo, strconv.FormatUint(samples&math.MaxUint32, cBase))
+	x.setSpeed(cSampleRate, strconv.Itoa(sampleRate))
+}
+
+// speed returns the trimmed text of the element `tag` of `SPEED` or an empty string if there is none.
+func (x *IXML) speed(tag string) string {
+	start, end, empty := x.element(cSpeed, 0, len(x.doc))
+	if start < 0 || empty {
+		return ""
+	}
+	return x.text(tag, start, end)
+}
+
+// setSpeed sets the text of the element `tag` of `SPEED`.  It inserts the element at the end of `SPEED`,
+// which is created if needed, when it is missing.
+func (x *IXML) setSpeed(tag string, value string) {
+	start, end, empty := x.element(cSpeed, 0, len(x.doc))
+	if start < 0 {
+		_, i, empty := x.element(cBWFXML, 0, len(x.doc))
+		if i < 0 || empty {
+			i = len(x.doc)
+		}
+		x.doc = x.doc[:i] + cIXMLSpeedEmpty + x.doc[i:]
+		start, end, empty = x.element(cSpeed, 0, len(x.doc))
+	}
+	if empty {
+		x.doc = x.doc[:start] + ">\n</" + cSpeed + ">" + x.doc[start+len(cSelfClosing):]
+		start, end = start+1, start+2
+	}
+	s, e, empty := x.element(tag, start, end)
+	switch {
+	case s < 0:
+		x.doc = x.doc[:end] + "<" + tag + ">" + value + "</" + tag + ">\n" + x.doc[end:]
+	case empty:
+		x.doc = x.doc[:s] + ">" + value + "</" + tag + ">" + x.doc[s+len(cSelfClosing):]
+	default:
+		x.doc = x.doc[:s] + value + x.doc[e:]
+	}
+}
+
+// text returns the trimmed text of the first element `tag` of doc[from:to] or an empty string if there is
+// none.
+func (x *IXML) text(tag string, from int, to int) string {
+	start, end, _ := x.element(tag, from, to)
+	if start < 0 {
+		return ""
+	}
+	return strings.TrimSpace(x.doc[start:end])
+}
+
+// element returns the start and end of the text of the first element `tag` of doc[from:to] or -1 and -1.
+// The start tag may have attributes and the tags may have whitespace, e.g., `<SPEED >` or
+// `</SPEED >`.  A self-closing element, e.g., `<SPEED/>`, has an empty text starting at its `/>` and
+// `empty` true.
+func (x *IXML) element(tag string, from int, to int) (start int, end int, empty bool) {
+	i := x.tagEnd("<"+tag, from, to)
+	if i < 0 {
+		return -1, -1, false
+	}
+	gt := strings.IndexByte(x.doc[i:to], '>')
+	if gt < 0 {
+		return -1, -1, false
+	}
+	gt += i
+	if x.doc[gt-1] == '/' {
+		return gt - 1, gt - 1, true
+	}
+	end = x.closeTag(tag, gt+1, to)
+	if end < 0 {
+		return -1, -1, false
+	}
+	return gt + 1, end, false
+}
+
+// closeTag returns the position of the first end tag `tag` of doc[from:to] or -1.
+func (x *IXML) closeTag(tag string, from int, to int) int {
+	for {
+		i := x.tagEnd("</"+tag, from, to)
+		if i < 0 {
+			return -1
+		}
+		j := i
+		for j < to && isSpace(x.doc[j]) {
+			j++
+		}
+		if j < to && x.doc[j] == '>' {
+			return i - len(tag) - len("</")
+		}
+		from = i
+	}
+}
+
+// tagEnd returns the position after the first `prefix`, e.g., `<SPEED`, of doc[from:to] that is followed
+// by whitespace, `>` or `/`, i.e., that is not the prefix of a longer tag name, or -1.
+func (x *IXML) tagEnd(prefix string, from int, to int) int {
+	for {
+		i := strings.Index(x.doc[from:to], prefix)
+		if i < 0 {
+			return -1
+		}
+		i += from + len(prefix)
+		if i < to && (x.doc[i] == '>' || x.doc[i] == '/' || isSpace(x.doc[i])) {
+			return i
+		}
+		from = i
+	}
+}
+
+// isSpace returns true if `c` is XML whitespace.
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// formatRate returns the iXML rate of `r`, e.g., `30000/1001` for 29.97 FPS.
+func formatRate(r timecode.Rate) string {
+	num, den := r.Rational()
+	return strconv.FormatInt(num, cBase) + "/" + strconv.FormatInt(den, cBase)
+}
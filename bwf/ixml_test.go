@@ -0,0 +1,130 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package bwf
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wunderbarb/timecode"
+)
+
+func TestIXML_Rate(t *testing.T) {
+	_, assert := Describe(t)
+
+	tests := []struct {
+		rate    string
+		flag    string
+		want    timecode.Rate
+		wantErr bool
+	}{
+		{"24/1", "NDF", timecode.Rate24, false},
+		{"25", "", timecode.Rate25, false},
+		{"30000/1001", "DF", timecode.Rate2997DF, false},
+		{"24000/1001", "NDF", timecode.Rate23976, false},
+		{"2997/100", "DF", timecode.Rate2997DF, false},
+		{"23.976", "", timecode.Rate23976, false},
+		{"", "", timecode.Rate{}, true},
+		{"24/0", "", timecode.Rate{}, true},
+		{"fast", "", timecode.Rate{}, true},
+	}
+	for i, tt := range tests {
+		x := NewIXML()
+		if tt.rate != "" {
+			x.setSpeed(cTimecodeRate, tt.rate)
+			x.setSpeed(cTimecodeFlag, tt.flag)
+		}
+		r, err := x.Rate()
+		assert.Equal(tt.wantErr, err != nil, "sample %d", i+1)
+		assert.Equal(tt.want, r, "sample %d", i+1)
+	}
+}
+
+func TestIXML_setTimecode(t *testing.T) {
+	require, assert := Describe(t)
+
+	x, err := ParseIXML([]byte("<BWFXML><PROJECT>p</PROJECT></BWFXML>\x00\x00"))
+	require.NoError(err)
+	x.setTimecode(timecode.Rate2997DF, 1<<32+7, 48000)
+	assert.Equal("30000/1001", x.Get(cTimecodeRate))
+	assert.Equal("DF", x.Get(cTimecodeFlag))
+	assert.Equal(uint64(1<<32+7), x.samples())
+	assert.Equal(48000, x.sampleRate())
+	doc := string(x.Bytes())
+	assert.True(strings.HasSuffix(doc, "</SPEED>\n</BWFXML>"))
+	assert.Equal("p", x.Get("PROJECT"))
+
+	x.setTimecode(timecode.Rate25, 10, 96000)
+	assert.Equal(1, strings.Count(string(x.Bytes()), "<SPEED>"))
+	assert.Equal("25/1", x.Get(cTimecodeRate))
+	assert.Equal("NDF", x.Get(cTimecodeFlag))
+	assert.Equal(uint64(10), x.samples())
+
+	_, err = ParseIXML([]byte("<xml/>"))
+	assert.ErrorIs(err, ErrInvalidChunk)
+}
+
+func TestIXML_speed(t *testing.T) {
+	require, assert := Describe(t)
+
+	// Only the elements of SPEED are timecode elements, whatever their attributes and whitespace.
+	tests := []struct {
+		doc     string
+		want    timecode.Rate
+		wantDoc string
+	}{
+		{"<BWFXML><HISTORY><TIMECODE_RATE>24/1</TIMECODE_RATE></HISTORY><SPEED >\n" +
+			"<TIMECODE_RATE id=\"1\" > 25/1 </TIMECODE_RATE >\n<TIMECODE_FLAG>NDF</TIMECODE_FLAG>\n" +
+			"</SPEED ></BWFXML>", timecode.Rate25,
+			"<BWFXML><HISTORY><TIMECODE_RATE>24/1</TIMECODE_RATE></HISTORY><SPEED >\n" +
+				"<TIMECODE_RATE id=\"1\" >30000/1001</TIMECODE_RATE >\n<TIMECODE_FLAG>DF</TIMECODE_FLAG>\n" +
+				"<TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_HI>0</TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_HI>\n" +
+				"<TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_LO>7</TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_LO>\n" +
+				"<TIMESTAMP_SAMPLE_RATE>48000</TIMESTAMP_SAMPLE_RATE>\n</SPEED ></BWFXML>"},
+		{"<BWFXML><SPEED><TIMECODE_RATEX>25/1</TIMECODE_RATEX><TIMECODE_RATE/></SPEED></BWFXML>",
+			timecode.Rate{},
+			"<BWFXML><SPEED><TIMECODE_RATEX>25/1</TIMECODE_RATEX><TIMECODE_RATE>30000/1001</TIMECODE_RATE>" +
+				"<TIMECODE_FLAG>DF</TIMECODE_FLAG>\n" +
+				"<TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_HI>0</TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_HI>\n" +
+				"<TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_LO>7</TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_LO>\n" +
+				"<TIMESTAMP_SAMPLE_RATE>48000</TIMESTAMP_SAMPLE_RATE>\n</SPEED></BWFXML>"},
+		{"<BWFXML version=\"1\"><TIMECODE_RATE>25/1</TIMECODE_RATE><SPEED /></BWFXML >", timecode.Rate{},
+			"<BWFXML version=\"1\"><TIMECODE_RATE>25/1</TIMECODE_RATE><SPEED >\n" +
+				"<TIMECODE_RATE>30000/1001</TIMECODE_RATE>\n<TIMECODE_FLAG>DF</TIMECODE_FLAG>\n" +
+				"<TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_HI>0</TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_HI>\n" +
+				"<TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_LO>7</TIMESTAMP_SAMPLES_SINCE_MIDNIGHT_LO>\n" +
+				"<TIMESTAMP_SAMPLE_RATE>48000</TIMESTAMP_SAMPLE_RATE>\n</SPEED></BWFXML >"},
+	}
+	for i, tt := range tests {
+		x, err := ParseIXML([]byte(tt.doc))
+		require.NoError(err, "sample %d", i+1)
+		r, _ := x.Rate()
+		assert.Equal(tt.want, r, "sample %d", i+1)
+		x.setTimecode(timecode.Rate2997DF, 7, 48000)
+		assert.Equal(tt.wantDoc, string(x.Bytes()), "sample %d", i+1)
+		r, err = x.Rate()
+		require.NoError(err, "sample %d", i+1)
+		assert.Equal(timecode.Rate2997DF, r, "sample %d", i+1)
+		assert.Equal(uint64(7), x.samples(), "sample %d", i+1)
+	}
+}
+
+func TestFormatRate(t *testing.T) {
+	_, assert := Describe(t)
+
+	tests := []struct {
+		fps  float64
+		want string
+	}{
+		{24, "24/1"},
+		{timecode.FPS2997, "30000/1001"},
+		{timecode.FPS23976fps, "24000/1001"},
+		{2 * timecode.FPS2997, "60000/1001"},
+		{12.5, "12500/1000"},
+	}
+	for i, tt := range tests {
+		assert.Equal(tt.want, formatRate(timecode.Rate{FPS: tt.fps}), "sample %d", i+1)
+	}
+}
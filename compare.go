@@ -0,0 +1,90 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Compare returns -1 if `a` is before `b`, 0 if they are equal and +1 if `a` is after `b`.  It is
+// compatible with slices.SortFunc.  It panics if their frame rate or drop frame differ because such
+// timecodes have no order.
+func Compare(a Timecode, b Timecode) int {
+	if !a.sameFrameRate(b) {
+		panic(fmt.Sprintf("timecode: cannot compare %+v and %+v: %v", &a, &b, ErrInconsistentFPS))
+	}
+	switch {
+	case a.currentFrame < b.currentFrame:
+		return -1
+	case a.currentFrame > b.currentFrame:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Min returns the earliest of the timecodes.  They must have the same frame rate and drop frame.
+func Min(tc Timecode, others ...Timecode) (Timecode, error) {
+	return pick(tc, others, -1)
+}
+
+// Max returns the latest of the timecodes.  They must have the same frame rate and drop frame.
+func Max(tc Timecode, others ...Timecode) (Timecode, error) {
+	return pick(tc, others, 1)
+}
+
+// Between returns true if the timecode `t` is between `first` and `last` included.  The three
+// timecodes must have the same frame rate and drop frame.
+func (t *Timecode) Between(first Timecode, last Timecode) (bool, error) {
+	if !t.sameFrameRate(first) || !t.sameFrameRate(last) {
+		return false, ErrInconsistentFPS
+	}
+	return first.currentFrame <= t.currentFrame && t.currentFrame <= last.currentFrame, nil
+}
+
+// Timecodes is a slice of timecodes that implements sort.Interface.  Less panics if two timecodes have
+// different frame rates or drop frames.  Sort checks them first and returns an error instead.
+type Timecodes []Timecode
+
+// Len implements sort.Interface.
+func (ts Timecodes) Len() int {
+	return len(ts)
+}
+
+// Less implements sort.Interface.
+func (ts Timecodes) Less(i int, j int) bool {
+	return Compare(ts[i], ts[j]) < 0
+}
+
+// Swap implements sort.Interface.
+func (ts Timecodes) Swap(i int, j int) {
+	ts[i], ts[j] = ts[j], ts[i]
+}
+
+// Sort sorts the timecodes in increasing order.  The slice is left unchanged if the timecodes do not
+// all have the same frame rate and drop frame.
+func (ts Timecodes) Sort() error {
+	for i := 1; i < len(ts); i++ {
+		if !ts[0].sameFrameRate(ts[i]) {
+			return ErrInconsistentFPS
+		}
+	}
+	sort.Stable(ts)
+	return nil
+}
+
+// pick returns the timecode for which Compare returns `sign` against all the others.
+func pick(tc Timecode, others []Timecode, sign int) (Timecode, error) {
+	for _, o := range others {
+		if !tc.sameFrameRate(o) {
+			return Timecode{}, ErrInconsistentFPS
+		}
+		if Compare(o, tc) == sign {
+			tc = o
+		}
+	}
+	return tc, nil
+}
@@ -0,0 +1,99 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	require, assert := Describe(t)
+
+	t1, _ := NewFromFrame(cFPS25, 10)
+	t2, _ := NewFromFrame(cFPS25, 11)
+	assert.Equal(-1, Compare(*t1, *t2))
+	assert.Equal(1, Compare(*t2, *t1))
+	assert.Equal(0, Compare(*t1, *Clone(t1)))
+	assert.True(t1.Before(*t2))
+	assert.False(t1.Before(*t1))
+	assert.True(t2.After(*t1))
+	assert.False(t2.After(*t2))
+
+	t3, _ := NewFromFrame(cFPS24, 10)
+	assert.PanicsWithValue("timecode: cannot compare 00:00:00:10@25 and 00:00:00:10@24: inconsistent fps",
+		func() { Compare(*t1, *t3) })
+	assert.Panics(func() { t1.Before(*t3) })
+
+	ts := []Timecode{*t2, *t1}
+	slices.SortFunc(ts, Compare)
+	require.Equal(10, ts[0].Frame())
+}
+
+func TestMinMax(t *testing.T) {
+	require, assert := Describe(t)
+
+	t1, _ := NewFromFrame(cFPS25, 10)
+	t2, _ := NewFromFrame(cFPS25, 30)
+	t3, _ := NewFromFrame(cFPS25, 20)
+	m, err := Min(*t3, *t2, *t1)
+	require.NoError(err)
+	assert.Equal(10, m.Frame())
+	m, err = Max(*t3, *t2, *t1)
+	require.NoError(err)
+	assert.Equal(30, m.Frame())
+	m, _ = Max(*t1)
+	assert.Equal(10, m.Frame())
+
+	df, _ := NewWithDropFrame(0)
+	_, err = Min(*t1, *df)
+	assert.ErrorIs(err, ErrInconsistentFPS)
+}
+
+func TestTimecode_Between(t *testing.T) {
+	_, assert := Describe(t)
+
+	t1, _ := NewFromFrame(cFPS25, 10)
+	t2, _ := NewFromFrame(cFPS25, 20)
+	tests := []struct {
+		frame      int
+		expBetween bool
+	}{
+		{9, false},
+		{10, true},
+		{15, true},
+		{20, true},
+		{21, false},
+	}
+	for i, tt := range tests {
+		tc, _ := NewFromFrame(cFPS25, tt.frame)
+		b, err := tc.Between(*t1, *t2)
+		assert.NoError(err, "sample %d", i+1)
+		assert.Equal(tt.expBetween, b, "sample %d", i+1)
+	}
+	t3, _ := NewFromFrame(cFPS24, 15)
+	_, err := t3.Between(*t1, *t2)
+	assert.ErrorIs(err, ErrInconsistentFPS)
+}
+
+func TestTimecodes_Sort(t *testing.T) {
+	require, assert := Describe(t)
+
+	var ts Timecodes
+	for _, f := range []int{5, 3, 9, 1} {
+		tc, _ := NewFromFrame(cFPS25, f)
+		ts = append(ts, *tc)
+	}
+	require.NoError(ts.Sort())
+	assert.True(sort.IsSorted(ts))
+	assert.Equal(1, ts[0].Frame())
+	assert.Equal(9, ts[3].Frame())
+
+	tc, _ := NewFromFrame(cFPS24, 0)
+	ts = append(ts, *tc)
+	assert.ErrorIs(ts.Sort(), ErrInconsistentFPS)
+	assert.Equal(1, ts[0].Frame())
+}
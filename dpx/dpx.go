@@ -0,0 +1,171 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+// Package dpx reads and writes the timecode and the user bits of the television header of DPX files
+// (SMPTE 268M).  The timecode is a 32-bit SMPTE 12M timecode, i.e., hours, minutes, seconds and frames in
+// binary coded decimal, in the byte order of the file.
+package dpx
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/wunderbarb/timecode"
+)
+
+var (
+	// ErrInvalidHeader is returned when the file is not a DPX file or its header is truncated.
+	ErrInvalidHeader = errors.New("dpx: invalid header")
+	// ErrNoTimecode is returned when the timecode of the header is undefined.
+	ErrNoTimecode = errors.New("dpx: no timecode")
+	// ErrNoRate is returned when both frame rates of the header are undefined.
+	ErrNoRate = errors.New("dpx: no frame rate")
+	// ErrRateMismatch is returned when the timecode to write does not have the frame rate of the header.
+	ErrRateMismatch = errors.New("dpx: rate mismatch")
+)
+
+// Undefined is the value of an undefined 32-bit field of a DPX header.
+const Undefined = 0xFFFFFFFF
+
+const (
+	// cMagic is the magic number `SDPX` in the byte order of the file.
+	cMagic               = 0x53445058
+	cOffsetFilmFrameRate = 1724
+	cOffsetTimeCode      = 1920
+	cOffsetUserBits      = 1924
+	cOffsetFrameRate     = 1940
+	cHeaderSize          = 1944
+	// cTimeCodeSize is the size of the timecode and the user bits.
+	cTimeCodeSize = 8
+)
+
+// Header holds the timecode fields of a DPX header.
+type Header struct {
+	// ByteOrder is the byte order of the file.
+	ByteOrder binary.ByteOrder
+	// TimeCode is the SMPTE 12M timecode of the television header or Undefined.
+	TimeCode uint32
+	// UserBits are the SMPTE 12M user bits of the television header or Undefined.
+	UserBits uint32
+	// FrameRate is the frame rate of the television header or 0 if undefined.
+	FrameRate float32
+	// FilmFrameRate is the frame rate of the motion picture film header or 0 if undefined.
+	FilmFrameRate float32
+}
+
+// ReadFile returns the header of the DPX file `name`.
+func ReadFile(name string) (*Header, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Read returns the header of the DPX file read from `r`.
+func Read(r io.Reader) (*Header, error) {
+	b := make([]byte, cHeaderSize)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, errors.Wrapf(ErrInvalidHeader, "%v", err)
+	}
+	h := &Header{}
+	switch uint32(cMagic) {
+	case binary.BigEndian.Uint32(b):
+		h.ByteOrder = binary.BigEndian
+	case binary.LittleEndian.Uint32(b):
+		h.ByteOrder = binary.LittleEndian
+	default:
+		return nil, errors.Wrapf(ErrInvalidHeader, "magic number %q", b[:4])
+	}
+	h.TimeCode = h.ByteOrder.Uint32(b[cOffsetTimeCode:])
+	h.UserBits = h.ByteOrder.Uint32(b[cOffsetUserBits:])
+	h.FrameRate = h.float(b[cOffsetFrameRate:])
+	h.FilmFrameRate = h.float(b[cOffsetFilmFrameRate:])
+	return h, nil
+}
+
+// Rate returns the frame rate of the television header or else of the film header.  A frame rate close
+// to an NTSC rate, e.g., 29.97, is snapped to it as by timecode.SnapNTSC.  The drop frame mode is set by
+// the timecode.
+func (h *Header) Rate() (timecode.Rate, error) {
+	fps := float64(h.FrameRate)
+	if fps <= 0 {
+		fps = float64(h.FilmFrameRate)
+	}
+	if fps <= 0 {
+		return timecode.Rate{}, ErrNoRate
+	}
+	return timecode.Rate{FPS: timecode.SnapNTSC(fps)}, nil
+}
+
+// Timecode returns the timecode of the television header at the rate of the header.  It returns ErrNoRate
+// if both frame rates are undefined.  The caller may then decode TimeCode with timecode.NewFromSMPTE12M at
+// a rate known otherwise.
+func (h *Header) Timecode() (*timecode.Timecode, error) {
+	if h.TimeCode == Undefined {
+		return nil, ErrNoTimecode
+	}
+	r, err := h.Rate()
+	if err != nil {
+		return nil, err
+	}
+	return timecode.NewFromSMPTE12M(r, h.TimeCode)
+}
+
+// WriteFile rewrites in place the timecode and the user bits of the DPX file `name` to `tc` and
+// `userBits`.
+func WriteFile(name string, tc timecode.Timecode, userBits uint32) error {
+	f, err := os.OpenFile(name, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	h, err := Read(f)
+	if err == nil {
+		err = h.Write(f, tc, userBits)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Write writes the timecode `tc` and the user bits `userBits` to the television header in `w`, i.e., the
+// file the header was read from, and updates the header.  The frame rate of `tc` must be the rate of the
+// header.  If the frame rate of the television header is undefined, it is set to the frame rate of `tc`.
+func (h *Header) Write(w io.WriterAt, tc timecode.Timecode, userBits uint32) error {
+	if r, err := h.Rate(); err == nil && r.FPS != tc.Rate().FPS {
+		return errors.Wrapf(ErrRateMismatch, "%v for a header at %v", tc.Rate(), r)
+	}
+	v := tc.SMPTE12M()
+	var b [cTimeCodeSize]byte
+	h.ByteOrder.PutUint32(b[:], v)
+	h.ByteOrder.PutUint32(b[4:], userBits)
+	if _, err := w.WriteAt(b[:], cOffsetTimeCode); err != nil {
+		return err
+	}
+	if h.FrameRate <= 0 {
+		fps := float32(tc.Rate().FPS)
+		h.ByteOrder.PutUint32(b[:], math.Float32bits(fps))
+		if _, err := w.WriteAt(b[:4], cOffsetFrameRate); err != nil {
+			return err
+		}
+		h.FrameRate = fps
+	}
+	h.TimeCode, h.UserBits = v, userBits
+	return nil
+}
+
+// float returns the 32-bit float of `b` or 0 if it is undefined, i.e., a NaN such as Undefined.
+func (h *Header) float(b []byte) float32 {
+	f := math.Float32frombits(h.ByteOrder.Uint32(b))
+	if math.IsNaN(float64(f)) {
+		return 0
+	}
+	return f
+}
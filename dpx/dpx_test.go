@@ -0,0 +1,122 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package dpx
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wunderbarb/timecode"
+)
+
+var testCounter int
+
+func TestRead(t *testing.T) {
+	require, assert := Describe(t)
+
+	tests := []struct {
+		order    binary.ByteOrder
+		tc       uint32
+		fps      float32
+		filmFPS  float32
+		exp      string
+		expRate  timecode.Rate
+		expError error
+	}{
+		{binary.BigEndian, 0x01000000, 24, 0, "01:00:00:00", timecode.Rate24, nil},
+		{binary.LittleEndian, 0x10000042, 29.97, 0, "10:00:00;02", timecode.Rate2997DF, nil},
+		{binary.BigEndian, 0x00595923, math.Float32frombits(Undefined), 23.976, "00:59:59:23",
+			timecode.Rate23976, nil},
+		{binary.BigEndian, Undefined, 25, 0, "", timecode.Rate{}, ErrNoTimecode},
+		{binary.LittleEndian, 0x01000000, math.Float32frombits(Undefined), 0, "", timecode.Rate{}, ErrNoRate},
+		{binary.BigEndian, 0x0100001A, 25, 0, "", timecode.Rate{}, timecode.ErrInvalidTimeCode},
+	}
+	for i, tt := range tests {
+		h, err := Read(bytes.NewReader(newDPX(tt.order, tt.tc, tt.fps, tt.filmFPS)))
+		require.NoError(err, "sample %d", i+1)
+		assert.Equal(tt.order, h.ByteOrder, "sample %d", i+1)
+		assert.Equal(tt.tc, h.TimeCode, "sample %d", i+1)
+		assert.Equal(uint32(0x12345678), h.UserBits, "sample %d", i+1)
+		tc, err := h.Timecode()
+		require.ErrorIs(err, tt.expError, "sample %d", i+1)
+		if err == nil {
+			assert.Equal(tt.exp, tc.String(), "sample %d", i+1)
+			assert.Equal(tt.expRate, tc.Rate(), "sample %d", i+1)
+		}
+	}
+
+	// Without rate, the timecode is decoded at a rate known otherwise.
+	h, err := Read(bytes.NewReader(newDPX(binary.BigEndian, 0x01000000, math.Float32frombits(Undefined), 0)))
+	require.NoError(err)
+	_, err = h.Rate()
+	assert.ErrorIs(err, ErrNoRate)
+	tc, err := timecode.NewFromSMPTE12M(timecode.Rate25, h.TimeCode)
+	require.NoError(err)
+	assert.Equal("01:00:00:00", tc.String())
+
+	_, err = Read(bytes.NewReader(make([]byte, cHeaderSize)))
+	assert.ErrorIs(err, ErrInvalidHeader)
+	_, err = Read(bytes.NewReader(newDPX(binary.BigEndian, 0, 24, 0)[:100]))
+	assert.ErrorIs(err, ErrInvalidHeader)
+	_, err = ReadFile("testdata/missing.dpx")
+	assert.Error(err)
+}
+
+func TestWriteFile(t *testing.T) {
+	require, assert := Describe(t)
+
+	name := filepath.Join(t.TempDir(), "shot.0001.dpx")
+	require.NoError(os.WriteFile(name, newDPX(binary.LittleEndian, Undefined, math.Float32frombits(Undefined),
+		0), 0o600))
+	tc, err := timecode.NewFromRate(timecode.Rate2997DF, 107892)
+	require.NoError(err)
+	require.NoError(WriteFile(name, *tc, 0xCAFE))
+	h, err := ReadFile(name)
+	require.NoError(err)
+	assert.Equal(uint32(0x01000040), h.TimeCode)
+	assert.Equal(uint32(0xCAFE), h.UserBits)
+	assert.InDelta(29.97, h.FrameRate, 1e-3)
+	tc1, err := h.Timecode()
+	require.NoError(err)
+	assert.Equal("01:00:00;00", tc1.String())
+
+	tc, _ = timecode.NewFromRate(timecode.Rate25, 0)
+	assert.ErrorIs(WriteFile(name, *tc, 0), ErrRateMismatch)
+	assert.Error(WriteFile(filepath.Join(t.TempDir(), "missing.dpx"), *tc, 0))
+}
+
+// newDPX returns a DPX header in the byte order `order` with the timecode `tc`, the user bits 0x12345678
+// and the television and film frame rates `fps` and `filmFPS`.
+func newDPX(order binary.ByteOrder, tc uint32, fps float32, filmFPS float32) []byte {
+	b := make([]byte, 2048)
+	order.PutUint32(b, cMagic)
+	order.PutUint32(b[cOffsetFilmFrameRate:], math.Float32bits(filmFPS))
+	order.PutUint32(b[cOffsetTimeCode:], tc)
+	order.PutUint32(b[cOffsetUserBits:], 0x12345678)
+	order.PutUint32(b[cOffsetFrameRate:], math.Float32bits(fps))
+	return b
+}
+
+func Describe(t *testing.T, msg ...string) (*require.Assertions,
+	*assert.Assertions) {
+
+	dispMsg := ""
+	if len(msg) != 0 {
+		dispMsg = msg[0]
+	}
+	name := strings.TrimPrefix(strings.TrimPrefix(t.Name(), "Test"), "_")
+	fmt.Printf("Test %d: %s %s\n", testCounter, name, dispMsg)
+	testCounter++
+	return require.New(t), assert.New(t)
+}
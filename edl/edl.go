@@ -0,0 +1,527 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+// Package edl reads and writes CMX3600 edit decision lists.  Every timecode of the list is a
+// timecode.Timecode.
+//
+// The lines that are not modified after parsing are written back byte for byte.  The other lines are
+// written with the usual CMX3600 column layout.
+package edl
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/wunderbarb/timecode"
+)
+
+var (
+	// ErrInvalidLine is returned when a line of the list cannot be parsed.
+	ErrInvalidLine = errors.New("edl: invalid line")
+	// ErrDuration is returned when the source and record durations of an edit are inconsistent.
+	ErrDuration = errors.New("edl: inconsistent durations")
+)
+
+const (
+	cTitle    = "TITLE:"
+	cFCM      = "FCM:"
+	cDrop     = "DROP FRAME"
+	cNonDrop  = "NON-DROP FRAME"
+	cM2       = "M2"
+	cComment  = "*"
+	cClipName = "* FROM CLIP NAME:"
+	cCut      = "C"
+	cBase     = 10
+)
+
+// Transition is the transition type of an edit, i.e., `C` for a cut, `D` for a dissolve, `Wnnn` for a wipe
+// or `K`, `KB` and `KO` for a key.
+type Transition string
+
+// EDL is a CMX3600 edit decision list.
+type EDL struct {
+	// Title is the title of the list.
+	Title string
+	// DropFrame is the frame code mode declared in the header.
+	DropFrame bool
+	// FPS is the frame rate of the timecodes.
+	FPS float64
+	// Events are the events of the list.
+	Events []*Event
+	// CRLF is true if the lines end with carriage return and line feed.
+	CRLF bool
+
+	header []item
+}
+
+// Event is an event of the list.  A cut has one edit.  A transition, e.g., a dissolve, has two edits
+// with the same event number.
+type Event struct {
+	Number int
+	// DropFrame is the frame code mode in effect for the event.
+	DropFrame bool
+	Edits     []Edit
+	// Speeds are the motion effects, i.e., the M2 lines.
+	Speeds []Speed
+	// Comments are the comment lines starting with `*`, e.g., `* FROM CLIP NAME: shot.mov`.
+	Comments []string
+	// Other are the unrecognized lines, e.g., `AUD 3`.
+	Other []string
+
+	items []item
+}
+
+// Edit is an edit line of an event.
+type Edit struct {
+	Reel string
+	// Track is the track type, e.g., `V`, `A`, `A2`, `AA` or `AA/V`.
+	Track      string
+	Transition Transition
+	// Duration is the duration of the transition in frames.
+	Duration  int
+	SourceIn  timecode.Timecode
+	SourceOut timecode.Timecode
+	RecordIn  timecode.Timecode
+	RecordOut timecode.Timecode
+}
+
+// Speed is a motion effect, i.e., an M2 line.
+type Speed struct {
+	Reel string
+	// FPS is the playback speed of the source in frames per second.  It is negative for reverse motion.
+	FPS float64
+	// Entry is the source timecode where the motion effect starts.
+	Entry timecode.Timecode
+}
+
+type itemKind int
+
+const (
+	kindEdit itemKind = iota
+	kindSpeed
+	kindComment
+	kindOther
+	// kindVerbatim is a line written as is, e.g., a blank line.
+	kindVerbatim
+	kindTitle
+	kindFCM
+)
+
+// item is a line of the list in its original order.  `parsed` is the canonical form of the line when it
+// was parsed.  If the canonical form did not change, `raw` is written back.
+type item struct {
+	kind   itemKind
+	idx    int
+	raw    string
+	parsed string
+}
+
+// Parse reads a CMX3600 edit decision list whose timecodes have the frame rate `fps`.  The `FCM` lines
+// select drop frame, which requires 29.97 FPS.
+func Parse(r io.Reader, fps float64) (*EDL, error) {
+	if fps <= 0 {
+		return nil, timecode.ErrInvalidFPS
+	}
+	p := parser{edl: &EDL{FPS: fps}}
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if line == "" && err == io.EOF {
+			break
+		}
+		p.line++
+		line = strings.TrimSuffix(line, "\n")
+		if strings.HasSuffix(line, "\r") {
+			p.edl.CRLF = true
+			line = strings.TrimSuffix(line, "\r")
+		}
+		if err := p.parseLine(line); err != nil {
+			return nil, err
+		}
+	}
+	if p.event != nil {
+		// Trailing FCM lines without a following event are kept verbatim.
+		for _, it := range p.pending {
+			p.event.items = append(p.event.items, item{kind: kindVerbatim, raw: it.raw})
+		}
+	}
+	p.flush()
+	return p.edl, nil
+}
+
+// parser holds the state of the parsing.
+type parser struct {
+	edl       *EDL
+	line      int
+	event     *Event
+	dropFrame bool
+	// pending are the lines preceding the next event, i.e., FCM lines.
+	pending []item
+}
+
+func (p *parser) parseLine(line string) error {
+	trimmed := strings.TrimSpace(line)
+	fields := strings.Fields(trimmed)
+	switch {
+	case strings.HasPrefix(trimmed, cTitle) && p.event == nil:
+		p.edl.Title = strings.TrimSpace(strings.TrimPrefix(trimmed, cTitle))
+		p.edl.header = append(p.edl.header, item{kind: kindTitle, raw: line, parsed: p.edl.formatTitle()})
+	case strings.HasPrefix(trimmed, cFCM):
+		return p.parseFCM(line, trimmed)
+	case trimmed == "":
+		p.add(kindVerbatim, line, line)
+	case strings.HasPrefix(trimmed, cComment):
+		p.add(kindComment, line, line)
+	case len(fields) > 0 && fields[0] == cM2:
+		return p.parseSpeed(line, fields)
+	case len(fields) > 0 && isEventNumber(fields[0]):
+		return p.parseEdit(line, fields)
+	default:
+		p.add(kindOther, line, line)
+	}
+	return nil
+}
+
+func (p *parser) parseFCM(line string, trimmed string) error {
+	mode := strings.TrimSpace(strings.TrimPrefix(trimmed, cFCM))
+	switch strings.ToUpper(mode) {
+	case cDrop:
+		if p.edl.FPS != timecode.FPS2997 {
+			return errors.Wrapf(timecode.ErrInvalidFPS, "edl: line %d", p.line)
+		}
+		p.dropFrame = true
+	case cNonDrop:
+		p.dropFrame = false
+	default:
+		return errors.Wrapf(ErrInvalidLine, "line %d", p.line)
+	}
+	it := item{kind: kindFCM, raw: line, parsed: formatFCM(p.dropFrame)}
+	if p.event == nil {
+		p.edl.DropFrame = p.dropFrame
+		p.edl.header = append(p.edl.header, it)
+		return nil
+	}
+	p.pending = append(p.pending, it)
+	return nil
+}
+
+func (p *parser) parseSpeed(line string, fields []string) error {
+	const cNumFields = 4
+	if p.event == nil || len(fields) != cNumFields {
+		return errors.Wrapf(ErrInvalidLine, "line %d", p.line)
+	}
+	speed, err := strconv.ParseFloat(fields[2], 64)
+	if err != nil {
+		return errors.Wrapf(ErrInvalidLine, "line %d", p.line)
+	}
+	entry, err := p.parseTimecode(fields[3])
+	if err != nil {
+		return err
+	}
+	s := Speed{Reel: fields[1], FPS: speed, Entry: *entry}
+	p.event.Speeds = append(p.event.Speeds, s)
+	p.event.items = append(p.event.items, item{kind: kindSpeed, idx: len(p.event.Speeds) - 1, raw: line,
+		parsed: s.format()})
+	return nil
+}
+
+func (p *parser) parseEdit(line string, fields []string) error {
+	const (
+		cNumCut        = 8
+		cNumTransition = 9
+	)
+	if len(fields) != cNumCut && len(fields) != cNumTransition {
+		return errors.Wrapf(ErrInvalidLine, "line %d", p.line)
+	}
+	num, _ := strconv.Atoi(fields[0])
+	e := Edit{Reel: fields[1], Track: fields[2], Transition: Transition(fields[3])}
+	tcs := fields[4:]
+	if len(fields) == cNumTransition {
+		d, err := strconv.Atoi(fields[4])
+		if err != nil {
+			return errors.Wrapf(ErrInvalidLine, "line %d", p.line)
+		}
+		e.Duration = d
+		tcs = fields[5:]
+	}
+	dst := []*timecode.Timecode{&e.SourceIn, &e.SourceOut, &e.RecordIn, &e.RecordOut}
+	for i, s := range tcs {
+		tc, err := p.parseTimecode(s)
+		if err != nil {
+			return err
+		}
+		*dst[i] = *tc
+	}
+	if p.event == nil || p.event.Number != num || len(p.pending) != 0 {
+		p.flush()
+		p.event = &Event{Number: num, DropFrame: p.dropFrame, items: p.pending}
+		p.pending = nil
+	}
+	p.event.Edits = append(p.event.Edits, e)
+	p.event.items = append(p.event.items, item{kind: kindEdit, idx: len(p.event.Edits) - 1, raw: line,
+		parsed: e.format(num)})
+	return nil
+}
+
+// parseTimecode parses a timecode with the current frame code mode.  In drop frame, the `:` separator
+// is accepted before the frames.
+func (p *parser) parseTimecode(s string) (*timecode.Timecode, error) {
+	const cSep = 8
+	if !p.dropFrame {
+		tc, err := timecode.NewFromString(p.edl.FPS, s)
+		return tc, errors.Wrapf(err, "edl: line %d", p.line)
+	}
+	if len(s) > cSep && s[cSep] == ':' {
+		s = s[:cSep] + ";" + s[cSep+1:]
+	}
+	tc, err := timecode.NewWithDropFrameFromString(s)
+	return tc, errors.Wrapf(err, "edl: line %d", p.line)
+}
+
+// add appends the line to the current event or, before the first event, to the header.
+func (p *parser) add(kind itemKind, line string, value string) {
+	if p.event == nil {
+		p.edl.header = append(p.edl.header, item{kind: kindOther, raw: line, parsed: line})
+		return
+	}
+	if len(p.pending) != 0 {
+		// The lines between an FCM line and the next event are kept verbatim with the next event.
+		p.pending = append(p.pending, item{kind: kindVerbatim, raw: line})
+		return
+	}
+	e := p.event
+	idx := 0
+	switch kind {
+	case kindVerbatim:
+	case kindComment:
+		e.Comments = append(e.Comments, value)
+		idx = len(e.Comments) - 1
+	default:
+		e.Other = append(e.Other, value)
+		idx = len(e.Other) - 1
+	}
+	e.items = append(e.items, item{kind: kind, idx: idx, raw: line, parsed: value})
+}
+
+// flush appends the current event to the list.
+func (p *parser) flush() {
+	if p.event != nil {
+		p.edl.Events = append(p.edl.Events, p.event)
+	}
+	p.event = nil
+}
+
+// Write writes the list.
+func (e *EDL) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	eol := "\n"
+	if e.CRLF {
+		eol = "\r\n"
+	}
+	var lines []string
+	lines = append(lines, e.headerLines()...)
+	dropFrame := e.DropFrame
+	for _, ev := range e.Events {
+		lines = append(lines, ev.lines(dropFrame)...)
+		dropFrame = ev.DropFrame
+	}
+	for _, l := range lines {
+		if _, err := bw.WriteString(l + eol); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// String returns the list as written by Write.
+func (e *EDL) String() string {
+	var sb strings.Builder
+	_ = e.Write(&sb)
+	return sb.String()
+}
+
+// Validate verifies that the source duration of each edit matches its record duration.  For an edit
+// with a motion effect, the source duration must match the record duration at the speed of the effect.
+func (e *EDL) Validate() error {
+	for _, ev := range e.Events {
+		for _, ed := range ev.Edits {
+			if err := ed.validate(ev.speed(ed.Reel), e.FPS); err != nil {
+				return errors.Wrapf(err, "event %03d", ev.Number)
+			}
+		}
+	}
+	return nil
+}
+
+// ClipName returns the clip name of the comment `* FROM CLIP NAME:` or an empty string.
+func (ev *Event) ClipName() string {
+	for _, c := range ev.Comments {
+		if strings.HasPrefix(c, cClipName) {
+			return strings.TrimSpace(strings.TrimPrefix(c, cClipName))
+		}
+	}
+	return ""
+}
+
+// speed returns the motion effect of the reel or nil.
+func (ev *Event) speed(reel string) *Speed {
+	for i := range ev.Speeds {
+		if ev.Speeds[i].Reel == reel {
+			return &ev.Speeds[i]
+		}
+	}
+	return nil
+}
+
+// lines returns the lines of the event.  `dropFrame` is the frame code mode of the previous event.
+func (ev *Event) lines(dropFrame bool) []string {
+	var res []string
+	hasFCM := false
+	count := map[itemKind]int{}
+	for _, it := range ev.items {
+		switch it.kind {
+		case kindFCM:
+			hasFCM = true
+			res = append(res, it.line(formatFCM(ev.DropFrame)))
+			continue
+		case kindVerbatim:
+			res = append(res, it.raw)
+			continue
+		case kindOther:
+			if it.idx < len(ev.Other) {
+				res = append(res, ev.Other[it.idx])
+			}
+		case kindComment:
+			if it.idx < len(ev.Comments) {
+				res = append(res, ev.Comments[it.idx])
+			}
+		case kindEdit:
+			if it.idx < len(ev.Edits) {
+				res = append(res, it.line(ev.Edits[it.idx].format(ev.Number)))
+			}
+		case kindSpeed:
+			if it.idx < len(ev.Speeds) {
+				res = append(res, it.line(ev.Speeds[it.idx].format()))
+			}
+		default:
+		}
+		count[it.kind]++
+	}
+	if !hasFCM && ev.DropFrame != dropFrame {
+		res = append([]string{formatFCM(ev.DropFrame)}, res...)
+	}
+	for _, ed := range ev.Edits[min(count[kindEdit], len(ev.Edits)):] {
+		res = append(res, ed.format(ev.Number))
+	}
+	for _, s := range ev.Speeds[min(count[kindSpeed], len(ev.Speeds)):] {
+		res = append(res, s.format())
+	}
+	res = append(res, ev.Comments[min(count[kindComment], len(ev.Comments)):]...)
+	res = append(res, ev.Other[min(count[kindOther], len(ev.Other)):]...)
+	return res
+}
+
+// headerLines returns the lines preceding the first event.
+func (e *EDL) headerLines() []string {
+	var res []string
+	hasTitle, hasFCM := false, false
+	for _, it := range e.header {
+		switch it.kind {
+		case kindTitle:
+			hasTitle = true
+			res = append(res, it.line(e.formatTitle()))
+		case kindFCM:
+			hasFCM = true
+			res = append(res, it.line(formatFCM(e.DropFrame)))
+		default:
+			res = append(res, it.raw)
+		}
+	}
+	if !hasTitle && e.Title != "" {
+		res = append([]string{e.formatTitle()}, res...)
+	}
+	if !hasFCM && len(e.header) == 0 {
+		res = append(res, formatFCM(e.DropFrame), "")
+	}
+	return res
+}
+
+// line returns the original line if the canonical form `s` did not change since parsing.
+func (it item) line(s string) string {
+	if s == it.parsed {
+		return it.raw
+	}
+	return s
+}
+
+func (e *EDL) formatTitle() string {
+	return cTitle + " " + e.Title
+}
+
+// format returns the edit line with the CMX3600 column layout.
+func (ed *Edit) format(num int) string {
+	dur := "   "
+	if ed.Transition != cCut && ed.Transition != "" {
+		dur = fmt.Sprintf("%03d", ed.Duration)
+	}
+	return fmt.Sprintf("%03d  %-8s %-5s %-4s %s %s %s %s %s", num, ed.Reel, ed.Track, ed.Transition, dur,
+		ed.SourceIn.String(), ed.SourceOut.String(), ed.RecordIn.String(), ed.RecordOut.String())
+}
+
+// validate verifies the durations of the edit.  `s` is the motion effect of the edit or nil.
+func (ed *Edit) validate(s *Speed, fps float64) error {
+	rec := ed.RecordIn.FrameCount(ed.RecordOut)
+	src := ed.SourceIn.FrameCount(ed.SourceOut)
+	if rec < 0 {
+		return errors.Wrapf(ErrDuration, "record out %s before record in %s", ed.RecordOut.String(),
+			ed.RecordIn.String())
+	}
+	exp := rec
+	if s != nil {
+		exp = int(math.Round(float64(rec) * math.Abs(s.FPS) / fps))
+		src = abs(src)
+	}
+	if src != exp {
+		return errors.Wrapf(ErrDuration, "source duration %d, record duration %d", src, rec)
+	}
+	return nil
+}
+
+// format returns the M2 line.
+func (s *Speed) format() string {
+	return fmt.Sprintf("M2   %-8s %05.1f                %s", s.Reel, s.FPS, s.Entry.String())
+}
+
+func formatFCM(dropFrame bool) string {
+	if dropFrame {
+		return cFCM + " " + cDrop
+	}
+	return cFCM + " " + cNonDrop
+}
+
+// isEventNumber returns true if `s` is an event number, i.e., three or more digits.
+func isEventNumber(s string) bool {
+	const cMinDigits = 3
+	if len(s) < cMinDigits {
+		return false
+	}
+	_, err := strconv.ParseUint(s, cBase, 32)
+	return err == nil
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
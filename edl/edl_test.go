@@ -0,0 +1,155 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package edl
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wunderbarb/timecode"
+)
+
+var testCounter int
+
+func TestParse(t *testing.T) {
+	require, assert := Describe(t)
+
+	data, err := os.ReadFile("testdata/ndf.edl")
+	require.NoError(err)
+	e, err := Parse(bytes.NewReader(data), 24)
+	require.NoError(err)
+	assert.Equal("REEL 1 CONFORM", e.Title)
+	assert.False(e.DropFrame)
+	assert.False(e.CRLF)
+	require.Len(e.Events, 4)
+
+	ev := e.Events[0]
+	assert.Equal(1, ev.Number)
+	assert.Equal("A001C003_230101.MOV", ev.ClipName())
+	require.Len(ev.Edits, 1)
+	assert.Equal("A001C003", ev.Edits[0].Reel)
+	assert.Equal(Transition(cCut), ev.Edits[0].Transition)
+	assert.Equal("01:00:10:00", ev.Edits[0].SourceIn.String())
+	assert.Equal(120, ev.Edits[0].RecordOut.Frame())
+
+	ev = e.Events[1]
+	require.Len(ev.Edits, 2)
+	assert.Equal(Transition("D"), ev.Edits[1].Transition)
+	assert.Equal(24, ev.Edits[1].Duration)
+	assert.Len(ev.Comments, 2)
+
+	ev = e.Events[2]
+	assert.Equal("AA/V", ev.Edits[0].Track)
+	require.Len(ev.Speeds, 1)
+	assert.Equal(60.0, ev.Speeds[0].FPS)
+	assert.Equal("04:00:00:00", ev.Speeds[0].Entry.String())
+
+	ev = e.Events[3]
+	assert.Equal(Transition("W001"), ev.Edits[0].Transition)
+	assert.Equal([]string{"AUD  3"}, ev.Other)
+	assert.NoError(e.Validate())
+
+	_, err = Parse(strings.NewReader("001  AX V C 00:00:00:00 00:00:01:00 00:00:00:00\n"), 24)
+	assert.ErrorIs(err, ErrInvalidLine)
+	_, err = Parse(strings.NewReader("001  AX V C 00:00:00:00 00:00:01:00 00:00:00:00 00:00:01:25\n"), 24)
+	assert.ErrorIs(err, timecode.ErrInconsistentFPS)
+	_, err = Parse(strings.NewReader("FCM: DROP FRAME\n"), 24)
+	assert.ErrorIs(err, timecode.ErrInvalidFPS)
+}
+
+func TestParse_DropFrame(t *testing.T) {
+	require, assert := Describe(t)
+
+	data, err := os.ReadFile("testdata/df.edl")
+	require.NoError(err)
+	e, err := Parse(bytes.NewReader(data), timecode.FPS2997)
+	require.NoError(err)
+	assert.True(e.DropFrame)
+	assert.True(e.CRLF)
+	require.Len(e.Events, 2)
+	assert.True(e.Events[0].DropFrame)
+	assert.Equal(1798, e.Events[0].Edits[0].SourceIn.Frame())
+	assert.False(e.Events[1].DropFrame)
+	assert.NoError(e.Validate())
+}
+
+func TestEDL_Write(t *testing.T) {
+	require, assert := Describe(t)
+
+	for _, tt := range []struct {
+		file string
+		fps  float64
+	}{
+		{"testdata/ndf.edl", 24},
+		{"testdata/df.edl", timecode.FPS2997},
+	} {
+		data, err := os.ReadFile(tt.file)
+		require.NoError(err)
+		e, err := Parse(bytes.NewReader(data), tt.fps)
+		require.NoError(err)
+		var buf bytes.Buffer
+		require.NoError(e.Write(&buf))
+		assert.Equal(string(data), buf.String(), tt.file)
+	}
+
+	// Modified and new lines use the CMX3600 layout.
+	data, _ := os.ReadFile("testdata/ndf.edl")
+	e, _ := Parse(bytes.NewReader(data), 24)
+	e.Events[0].Edits[0].RecordOut.Offset(1)
+	e.Events[0].Edits[0].SourceOut.Offset(1)
+	in, _ := timecode.NewFromString(24, "00:00:10:12")
+	out, _ := timecode.NewFromString(24, "00:00:11:12")
+	e.Events = append(e.Events, &Event{Number: 5, Edits: []Edit{{Reel: "AX", Track: "V", Transition: cCut,
+		SourceIn: *in, SourceOut: *out, RecordIn: *in, RecordOut: *out}},
+		Comments: []string{"* FROM CLIP NAME: NEW.MOV"}})
+	s := e.String()
+	assert.Contains(s, "001  A001C003 V     C        01:00:10:00 01:00:15:01 00:00:00:00 00:00:05:01\n")
+	assert.True(strings.HasSuffix(s, "AUD  3\n"+
+		"005  AX       V     C        00:00:10:12 00:00:11:12 00:00:10:12 00:00:11:12\n"+
+		"* FROM CLIP NAME: NEW.MOV\n"))
+	assert.NoError(e.Validate())
+
+	e = &EDL{Title: "NEW", FPS: 25}
+	assert.Equal("TITLE: NEW\nFCM: NON-DROP FRAME\n\n", e.String())
+}
+
+func TestEDL_Validate(t *testing.T) {
+	require, assert := Describe(t)
+
+	src := "001  AX       V     C        00:00:00:00 00:00:01:00 00:00:00:00 00:00:01:01\n"
+	e, err := Parse(strings.NewReader(src), 25)
+	require.NoError(err)
+	assert.ErrorIs(e.Validate(), ErrDuration)
+
+	src = "001  AX       V     C        00:00:00:00 00:00:01:00 00:00:00:00 00:00:02:00\n" +
+		"M2   AX       012.5                00:00:00:00\n"
+	e, err = Parse(strings.NewReader(src), 25)
+	require.NoError(err)
+	assert.NoError(e.Validate())
+	e.Events[0].Speeds[0].FPS = -25
+	assert.ErrorIs(e.Validate(), ErrDuration)
+}
+
+// Describe displays the rank of the test, the name of the function
+// and its optional description provided by 'msg'.  It initializes an assert
+// and a require function and returns them.
+func Describe(t *testing.T, msg ...string) (*require.Assertions,
+	*assert.Assertions) {
+
+	dispMsg := ""
+	if len(msg) != 0 {
+		dispMsg = msg[0]
+	}
+	name := strings.TrimPrefix(strings.TrimPrefix(t.Name(), "Test"), "_")
+	fmt.Printf("Test %d: %s %s\n", testCounter, name, dispMsg)
+	testCounter++
+	return require.New(t), assert.New(t)
+}
@@ -0,0 +1,452 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+// Package exr reads and writes the `timeCode` attribute of OpenEXR files, i.e., a 32-bit SMPTE 12M
+// timecode and its user bits, and reads the `framesPerSecond` attribute that gives its rate.
+//
+// Writing a timecode to a file without `timeCode` attribute inserts the attribute in the header and
+// shifts the offset tables that follow it.
+package exr
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/wunderbarb/timecode"
+)
+
+var (
+	// ErrInvalidHeader is returned when the file is not an OpenEXR file or its header is truncated or
+	// malformed.
+	ErrInvalidHeader = errors.New("exr: invalid header")
+	// ErrNoTimecode is returned when the header has no timeCode attribute.
+	ErrNoTimecode = errors.New("exr: no timeCode attribute")
+	// ErrNoRate is returned when the header has no valid framesPerSecond attribute.
+	ErrNoRate = errors.New("exr: no framesPerSecond attribute")
+	// ErrRateMismatch is returned when the timecode to write does not have the rate of the header.
+	ErrRateMismatch = errors.New("exr: rate mismatch")
+	// ErrUnsupported is returned when a timeCode attribute cannot be inserted because the size of the
+	// offset tables is unknown, e.g., for a single part tiled file.
+	ErrUnsupported = errors.New("exr: unsupported file layout")
+)
+
+const (
+	cMagic            = 20000630
+	cFlagTiled        = 0x200
+	cFlagMultipart    = 0x1000
+	cMaxNameSize      = 256
+	cAttrTimeCode     = "timeCode"
+	cAttrFramesPerSec = "framesPerSecond"
+	cAttrChunkCount   = "chunkCount"
+	cAttrDataWindow   = "dataWindow"
+	cAttrCompression  = "compression"
+	cTypeTimeCode     = "timecode"
+	cTypeRational     = "rational"
+	cTimeCodeSize     = 8
+	cRationalSize     = 8
+	cDataWindowSize   = 16
+	cOffsetSize       = 8
+	cPreambleSize     = 8
+	cIntSize          = 4
+	// cMaxValueSize is the largest value of a decoded attribute.
+	cMaxValueSize = 64
+)
+
+// _decoded are the attributes whose values are kept.  The values of the other attributes are skipped.
+var _decoded = map[string]bool{cAttrTimeCode: true, cAttrFramesPerSec: true, cAttrChunkCount: true,
+	cAttrDataWindow: true, cAttrCompression: true}
+
+// _linesPerChunk are the scan lines per chunk of each compression.
+var _linesPerChunk = []int{1, 1, 1, 16, 32, 16, 32, 32, 32, 256}
+
+// Rational is a rational number of an attribute.
+type Rational struct {
+	Num int32
+	Den uint32
+}
+
+// Header holds the timecode attributes of the header of the first part of an OpenEXR file.
+type Header struct {
+	// TimeCode is the SMPTE 12M timecode of the timeCode attribute, i.e., its timeAndFlags.
+	TimeCode uint32
+	// UserBits are the SMPTE 12M user bits of the timeCode attribute, i.e., its userData.
+	UserBits uint32
+	// FramesPerSecond is the framesPerSecond attribute or zero if absent.
+	FramesPerSecond Rational
+	// timeCodeOffset is the offset in the file of the value of the timeCode attribute or 0 if absent.
+	timeCodeOffset int64
+}
+
+// attribute is a decoded attribute of a header.  Its value starts at `offset` in the file.
+type attribute struct {
+	name   string
+	typ    string
+	offset int64
+	value  []byte
+}
+
+// part is the header of a part with its decoded attributes and its number of attributes `count`.  Its
+// terminating null byte is at `end` in the file.
+type part struct {
+	attributes []attribute
+	count      int
+	end        int64
+}
+
+// file is the structure of the headers of a file.  The offset tables start at `size`.
+type file struct {
+	version uint32
+	parts   []part
+	size    int64
+}
+
+// ReadFile returns the header of the OpenEXR file `name`.
+func ReadFile(name string) (*Header, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Read returns the header of the first part of the OpenEXR file read from `r`.
+func Read(r io.Reader) (*Header, error) {
+	f, err := readFile(r)
+	if err != nil {
+		return nil, err
+	}
+	return f.header(), nil
+}
+
+// Rate returns the rate of the framesPerSecond attribute.  A rate close to an NTSC rate, e.g., 2997/100, is
+// snapped to it as by timecode.SnapNTSC.  The drop frame mode is set by the timecode.
+func (h *Header) Rate() (timecode.Rate, error) {
+	if h.FramesPerSecond.Num <= 0 || h.FramesPerSecond.Den == 0 {
+		return timecode.Rate{}, ErrNoRate
+	}
+	fps := float64(h.FramesPerSecond.Num) / float64(h.FramesPerSecond.Den)
+	return timecode.Rate{FPS: timecode.SnapNTSC(fps)}, nil
+}
+
+// Timecode returns the timecode of the timeCode attribute at the rate of the framesPerSecond attribute.
+// It returns ErrNoRate without framesPerSecond attribute.  The caller may then decode TimeCode with
+// timecode.NewFromSMPTE12M at a rate known otherwise.
+func (h *Header) Timecode() (*timecode.Timecode, error) {
+	if h.timeCodeOffset == 0 {
+		return nil, ErrNoTimecode
+	}
+	r, err := h.Rate()
+	if err != nil {
+		return nil, err
+	}
+	return timecode.NewFromSMPTE12M(r, h.TimeCode)
+}
+
+// WriteFile writes the timecode `tc` and the user bits `userBits` to the timeCode attribute of the first
+// part of the OpenEXR file `name`.  An existing attribute is rewritten in place.  Otherwise, the file is
+// rewritten as by Write.
+func WriteFile(name string, tc timecode.Timecode, userBits uint32) error {
+	f, err := os.OpenFile(name, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	err = writeFile(f, tc, userBits)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Write copies the OpenEXR file `src` to `dst` with the timecode `tc` and the user bits `userBits` in
+// the timeCode attribute of the first part.  If the header has no timeCode attribute, Write inserts it,
+// and the framesPerSecond attribute if absent, at the end of the header and shifts the chunk offsets.
+// The rate of `tc` must be the rate of the framesPerSecond attribute, if any.
+func Write(dst io.Writer, src io.Reader, tc timecode.Timecode, userBits uint32) error {
+	b, err := io.ReadAll(src)
+	if err != nil {
+		return err
+	}
+	if b, err = stamp(b, tc, userBits); err != nil {
+		return err
+	}
+	_, err = dst.Write(b)
+	return err
+}
+
+// writeFile writes the timecode `tc` and the user bits `userBits` to the file `f`.
+func writeFile(f *os.File, tc timecode.Timecode, userBits uint32) error {
+	h, err := Read(f)
+	if err != nil {
+		return err
+	}
+	if err = h.checkRate(tc); err != nil {
+		return err
+	}
+	if h.timeCodeOffset > 0 {
+		_, err = f.WriteAt(newTimeCode(tc, userBits), h.timeCodeOffset)
+		return err
+	}
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return err
+	}
+	if b, err = stamp(b, tc, userBits); err != nil {
+		return err
+	}
+	// The stamped file is larger than the original one.
+	_, err = f.WriteAt(b, 0)
+	return err
+}
+
+// stamp returns the file `b` with the timecode `tc` and the user bits `userBits` in the timeCode
+// attribute of the first part.
+func stamp(b []byte, tc timecode.Timecode, userBits uint32) ([]byte, error) {
+	f, err := readFile(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	h := f.header()
+	if err = h.checkRate(tc); err != nil {
+		return nil, err
+	}
+	if h.timeCodeOffset > 0 {
+		copy(b[h.timeCodeOffset:], newTimeCode(tc, userBits))
+		return b, nil
+	}
+	tables, err := f.tables(int64(len(b)))
+	if err != nil {
+		return nil, err
+	}
+	for i := f.size; i < tables; i += cOffsetSize {
+		if offset := binary.LittleEndian.Uint64(b[i:]); offset < uint64(tables) || offset >= uint64(len(b)) {
+			return nil, errors.Wrapf(ErrInvalidHeader, "chunk offset %d out of %d..%d", offset, tables, len(b))
+		}
+	}
+	attrs := appendAttribute(nil, cAttrTimeCode, cTypeTimeCode, newTimeCode(tc, userBits))
+	if h.FramesPerSecond.Den == 0 {
+		attrs = appendAttribute(attrs, cAttrFramesPerSec, cTypeRational, newRational(tc.Rate()))
+	}
+	end := f.parts[0].end
+	res := make([]byte, 0, len(b)+len(attrs))
+	res = append(append(append(res, b[:end]...), attrs...), b[end:f.size]...)
+	for i := f.size; i < tables; i += cOffsetSize {
+		res = binary.LittleEndian.AppendUint64(res, binary.LittleEndian.Uint64(b[i:])+uint64(len(attrs)))
+	}
+	return append(res, b[tables:]...), nil
+}
+
+// checkRate returns ErrRateMismatch if the header has a rate that is not the rate of `tc`.
+func (h *Header) checkRate(tc timecode.Timecode) error {
+	if r, err := h.Rate(); err == nil && r.FPS != tc.Rate().FPS {
+		return errors.Wrapf(ErrRateMismatch, "%v for a header at %v", tc.Rate(), r)
+	}
+	return nil
+}
+
+// header returns the timecode attributes of the first part.
+func (f *file) header() *Header {
+	h := &Header{}
+	for _, a := range f.parts[0].attributes {
+		switch {
+		case a.name == cAttrTimeCode && a.typ == cTypeTimeCode && len(a.value) == cTimeCodeSize:
+			h.TimeCode = binary.LittleEndian.Uint32(a.value)
+			h.UserBits = binary.LittleEndian.Uint32(a.value[4:])
+			h.timeCodeOffset = a.offset
+		case a.name == cAttrFramesPerSec && a.typ == cTypeRational && len(a.value) == cRationalSize:
+			h.FramesPerSecond = Rational{Num: int32(binary.LittleEndian.Uint32(a.value)),
+				Den: binary.LittleEndian.Uint32(a.value[4:])}
+		}
+	}
+	return h
+}
+
+// tables returns the end of the offset tables of a file of `size` bytes.  The offset tables must fit in
+// the file.
+func (f *file) tables(size int64) (int64, error) {
+	n, err := f.chunks()
+	if err != nil {
+		return 0, err
+	}
+	tables := f.size + n*cOffsetSize
+	if n > size/cOffsetSize || tables > size {
+		return 0, errors.Wrapf(ErrInvalidHeader, "%d chunk offsets in %d bytes", n, size)
+	}
+	return tables, nil
+}
+
+// chunks returns the number of chunk offsets of the offset tables.  It is the sum of the chunkCount
+// attributes or, for a single part scan line file, the number of scan line blocks of the data window.
+func (f *file) chunks() (int64, error) {
+	var n int64
+	for _, p := range f.parts {
+		if a := p.find(cAttrChunkCount); len(a) == cIntSize {
+			count := int32(binary.LittleEndian.Uint32(a))
+			if count < 0 {
+				return 0, errors.Wrapf(ErrInvalidHeader, "chunkCount %d", count)
+			}
+			n += int64(count)
+			continue
+		}
+		if f.version&(cFlagTiled|cFlagMultipart) != 0 {
+			return 0, errors.Wrapf(ErrUnsupported, "no chunkCount in version %08X", f.version)
+		}
+		dw, c := p.find(cAttrDataWindow), p.find(cAttrCompression)
+		if len(dw) != cDataWindowSize || len(c) != 1 || int(c[0]) >= len(_linesPerChunk) {
+			return 0, errors.Wrapf(ErrInvalidHeader, "no dataWindow or compression")
+		}
+		yMin, yMax := int32(binary.LittleEndian.Uint32(dw[4:])), int32(binary.LittleEndian.Uint32(dw[12:]))
+		if yMax < yMin {
+			return 0, errors.Wrapf(ErrInvalidHeader, "dataWindow from line %d to %d", yMin, yMax)
+		}
+		lines := int64(_linesPerChunk[c[0]])
+		n += (int64(yMax) - int64(yMin) + lines) / lines
+	}
+	return n, nil
+}
+
+// find returns the value of the attribute `name` or nil.
+func (p part) find(name string) []byte {
+	for _, a := range p.attributes {
+		if a.name == name {
+			return a.value
+		}
+	}
+	return nil
+}
+
+// readFile reads the magic number, the version and the headers of the file `r`.
+func readFile(r io.Reader) (*file, error) {
+	br := &reader{r: bufio.NewReader(r)}
+	var pre [cPreambleSize]byte
+	if err := br.read(pre[:]); err != nil {
+		return nil, err
+	}
+	if binary.LittleEndian.Uint32(pre[:]) != cMagic {
+		return nil, errors.Wrapf(ErrInvalidHeader, "magic number %X", pre[:4])
+	}
+	f := &file{version: binary.LittleEndian.Uint32(pre[4:])}
+	for {
+		p, err := br.part()
+		if err != nil {
+			return nil, err
+		}
+		if p.count == 0 {
+			if len(f.parts) == 0 {
+				return nil, errors.Wrapf(ErrInvalidHeader, "empty header")
+			}
+			break
+		}
+		f.parts = append(f.parts, p)
+		if f.version&cFlagMultipart == 0 {
+			break
+		}
+	}
+	f.size = br.offset
+	return f, nil
+}
+
+// reader reads the headers of a file and counts the bytes read.
+type reader struct {
+	r      *bufio.Reader
+	offset int64
+}
+
+// read fills `b` with the next bytes.
+func (r *reader) read(b []byte) error {
+	if _, err := io.ReadFull(r.r, b); err != nil {
+		return errors.Wrapf(ErrInvalidHeader, "%v at %d", err, r.offset)
+	}
+	r.offset += int64(len(b))
+	return nil
+}
+
+// skip skips the next `n` bytes.
+func (r *reader) skip(n int64) error {
+	if _, err := io.CopyN(io.Discard, r.r, n); err != nil {
+		return errors.Wrapf(ErrInvalidHeader, "%v at %d", err, r.offset)
+	}
+	r.offset += n
+	return nil
+}
+
+// part reads the attributes of a header up to its terminating null byte.
+func (r *reader) part() (part, error) {
+	var p part
+	for {
+		end := r.offset
+		name, err := r.string()
+		if err != nil {
+			return part{}, err
+		}
+		if name == "" {
+			p.end = end
+			return p, nil
+		}
+		a := attribute{name: name}
+		if a.typ, err = r.string(); err != nil {
+			return part{}, err
+		}
+		var size [cIntSize]byte
+		if err = r.read(size[:]); err != nil {
+			return part{}, err
+		}
+		n := int32(binary.LittleEndian.Uint32(size[:]))
+		if n < 0 {
+			return part{}, errors.Wrapf(ErrInvalidHeader, "attribute %s of %d bytes", name, n)
+		}
+		a.offset = r.offset
+		p.count++
+		if !_decoded[name] || n > cMaxValueSize {
+			if err = r.skip(int64(n)); err != nil {
+				return part{}, err
+			}
+			continue
+		}
+		a.value = make([]byte, n)
+		if err = r.read(a.value); err != nil {
+			return part{}, err
+		}
+		p.attributes = append(p.attributes, a)
+	}
+}
+
+// string reads a null terminated string.
+func (r *reader) string() (string, error) {
+	s, err := r.r.ReadString(0)
+	if err != nil || len(s) > cMaxNameSize {
+		return "", errors.Wrapf(ErrInvalidHeader, "name at %d", r.offset)
+	}
+	r.offset += int64(len(s))
+	return s[:len(s)-1], nil
+}
+
+// appendAttribute appends the attribute `name` of type `typ` and value `value`.
+func appendAttribute(b []byte, name string, typ string, value []byte) []byte {
+	b = append(append(b, name...), 0)
+	b = append(append(b, typ...), 0)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(value)))
+	return append(b, value...)
+}
+
+// newTimeCode returns the value of the timeCode attribute of the timecode `tc` and the user bits
+// `userBits`.
+func newTimeCode(tc timecode.Timecode, userBits uint32) []byte {
+	return binary.LittleEndian.AppendUint32(binary.LittleEndian.AppendUint32(nil, tc.SMPTE12M()), userBits)
+}
+
+// newRational returns the value of the framesPerSecond attribute of the rate `r`, e.g., 30000/1001, as
+// by timecode.Rate.Rational.
+func newRational(r timecode.Rate) []byte {
+	num, den := r.Rational()
+	return binary.LittleEndian.AppendUint32(binary.LittleEndian.AppendUint32(nil, uint32(num)), uint32(den))
+}
@@ -0,0 +1,234 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package exr
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wunderbarb/timecode"
+)
+
+var testCounter int
+
+func TestRead(t *testing.T) {
+	require, assert := Describe(t)
+
+	tc := binary.LittleEndian.AppendUint32(binary.LittleEndian.AppendUint32(nil, 0x01000040), 0xBEEF)
+	tests := []struct {
+		attrs    []byte
+		exp      string
+		expRate  timecode.Rate
+		expError error
+	}{
+		{append(appendAttribute(nil, cAttrTimeCode, cTypeTimeCode, tc), newFPS(30000, 1001)...),
+			"01:00:00;00", timecode.Rate2997DF, nil},
+		{append(newFPS(24, 1), appendAttribute(nil, cAttrTimeCode, cTypeTimeCode, tc[:4])...), "",
+			timecode.Rate{}, ErrNoTimecode},
+		{appendAttribute(nil, cAttrTimeCode, cTypeTimeCode, tc), "", timecode.Rate{}, ErrNoRate},
+		{append(appendAttribute(nil, cAttrTimeCode, cTypeTimeCode, tc), newFPS(2997, 100)...),
+			"01:00:00;00", timecode.Rate2997DF, nil},
+	}
+	for i, tt := range tests {
+		h, err := Read(bytes.NewReader(newEXR(0, tt.attrs)))
+		require.NoError(err, "sample %d", i+1)
+		tc, err := h.Timecode()
+		require.ErrorIs(err, tt.expError, "sample %d", i+1)
+		if err == nil {
+			assert.Equal(tt.exp, tc.String(), "sample %d", i+1)
+			assert.Equal(tt.expRate, tc.Rate(), "sample %d", i+1)
+			assert.Equal(uint32(0xBEEF), h.UserBits, "sample %d", i+1)
+		}
+	}
+
+	// Without rate, the timecode is decoded at a rate known otherwise.
+	h, err := Read(bytes.NewReader(newEXR(0, appendAttribute(nil, cAttrTimeCode, cTypeTimeCode, tc))))
+	require.NoError(err)
+	_, err = h.Rate()
+	assert.ErrorIs(err, ErrNoRate)
+	tc1, err := timecode.NewFromSMPTE12M(timecode.Rate2997, h.TimeCode)
+	require.NoError(err)
+	assert.Equal("01:00:00;00", tc1.String())
+	assert.Equal(timecode.Rate2997DF, tc1.Rate())
+
+	_, err = Read(bytes.NewReader([]byte{0x76, 0x2F, 0x31, 0x02, 2, 0, 0, 0}))
+	assert.ErrorIs(err, ErrInvalidHeader)
+	b := newEXR(0, nil)
+	_, err = Read(bytes.NewReader(b[:30]))
+	assert.ErrorIs(err, ErrInvalidHeader)
+	_, err = Read(bytes.NewReader(append(b[:8:8], 0)))
+	assert.ErrorIs(err, ErrInvalidHeader)
+	_, err = ReadFile("testdata/missing.exr")
+	assert.Error(err)
+	// The declared sizes are not trusted.
+	for i, name := range []string{"channels", cAttrTimeCode} {
+		b = binary.LittleEndian.AppendUint32(nil, cMagic)
+		b = binary.LittleEndian.AppendUint32(b, 2)
+		b = append(append(b, name+"\x00x\x00"...), 0xFF, 0xFF, 0xFF, 0x7F, 1, 2)
+		_, err = Read(bytes.NewReader(b))
+		assert.ErrorIs(err, ErrInvalidHeader, "sample %d", i+1)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	require, assert := Describe(t)
+
+	tests := []struct {
+		version uint32
+		attrs   []byte
+		r       timecode.Rate
+		exp     string
+	}{
+		{2, nil, timecode.Rate2997DF, "10:00:00;00"},
+		{2, newFPS(24, 1), timecode.Rate24, "10:00:00:00"},
+		{2, append(appendAttribute(nil, cAttrTimeCode, cTypeTimeCode, make([]byte, cTimeCodeSize)),
+			newFPS(25, 1)...), timecode.Rate25, "10:00:00:00"},
+		{2 | cFlagMultipart, nil, timecode.Rate{FPS: 60}, "10:00:00:00"},
+		{2, nil, timecode.Rate{FPS: 12.5}, "10:00:00:00"},
+	}
+	for i, tt := range tests {
+		src := newEXR(tt.version, tt.attrs)
+		tc, err := timecode.NewFromFields(tt.r, 10, 0, 0, 0)
+		require.NoError(err, "sample %d", i+1)
+		var dst bytes.Buffer
+		require.NoError(Write(&dst, bytes.NewReader(src), *tc, 0x1234), "sample %d", i+1)
+		h, err := Read(bytes.NewReader(dst.Bytes()))
+		require.NoError(err, "sample %d", i+1)
+		tc1, err := h.Timecode()
+		require.NoError(err, "sample %d", i+1)
+		assert.Equal(tt.exp, tc1.String(), "sample %d", i+1)
+		assert.Equal(tt.r, tc1.Rate(), "sample %d", i+1)
+		assert.Equal(uint32(0x1234), h.UserBits, "sample %d", i+1)
+		assert.Equal(chunkLines(src), chunkLines(dst.Bytes()), "sample %d", i+1)
+	}
+
+	tc, _ := timecode.NewFromFields(timecode.Rate25, 1, 0, 0, 0)
+	var dst bytes.Buffer
+	assert.ErrorIs(Write(&dst, bytes.NewReader(newEXR(2, newFPS(24, 1))), *tc, 0), ErrRateMismatch)
+	assert.ErrorIs(Write(&dst, bytes.NewReader(newEXR(2|cFlagTiled, nil)), *tc, 0), ErrUnsupported)
+	assert.ErrorIs(Write(&dst, bytes.NewReader(newEXR(2, nil)[:80]), *tc, 0), ErrInvalidHeader)
+
+	// Chunk counts that do not match the offset tables.
+	tests1 := []struct {
+		version uint32
+		from    []byte
+		to      []byte
+	}{
+		{2 | cFlagMultipart, []byte{3, 0, 0, 0}, []byte{0x00, 0xFF, 0xFF, 0xFF}},
+		{2 | cFlagMultipart, []byte{3, 0, 0, 0}, []byte{0xFF, 0xFF, 0xFF, 0x7F}},
+		{2 | cFlagMultipart, []byte{3, 0, 0, 0}, []byte{4, 0, 0, 0}},
+		{2, []byte{39, 0, 0, 0}, []byte{0xF6, 0xFF, 0xFF, 0xFF}},
+	}
+	for i, tt := range tests1 {
+		b := bytes.Replace(newEXR(tt.version, nil), tt.from, tt.to, 1)
+		assert.ErrorIs(Write(&dst, bytes.NewReader(b), *tc, 0), ErrInvalidHeader, "sample %d", i+1)
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	require, assert := Describe(t)
+
+	name := filepath.Join(t.TempDir(), "shot.0001.exr")
+	require.NoError(os.WriteFile(name, newEXR(2, nil), 0o600))
+	for i, label := range []string{"01:00:00:00", "01:02:03:04"} {
+		tc, err := timecode.NewFromRate(timecode.Rate24, 0)
+		require.NoError(err)
+		require.NoError(tc.Parse(label))
+		require.NoError(WriteFile(name, *tc, uint32(i)), "sample %d", i+1)
+		h, err := ReadFile(name)
+		require.NoError(err, "sample %d", i+1)
+		tc1, err := h.Timecode()
+		require.NoError(err, "sample %d", i+1)
+		assert.Equal(label, tc1.String(), "sample %d", i+1)
+		assert.Equal(uint32(i), h.UserBits, "sample %d", i+1)
+		b, err := os.ReadFile(name)
+		require.NoError(err, "sample %d", i+1)
+		assert.Equal([]int32{0, 16, 32}, chunkLines(b), "sample %d", i+1)
+	}
+	tc, _ := timecode.NewFromRate(timecode.Rate25, 0)
+	assert.ErrorIs(WriteFile(name, *tc, 0), ErrRateMismatch)
+	assert.Error(WriteFile(filepath.Join(t.TempDir(), "missing.exr"), *tc, 0))
+}
+
+// newEXR returns a ZIP compressed scan line OpenEXR file of 40 lines, i.e., of three chunks, with the
+// version `version` and the extra attributes `attrs`.  A multipart file has a second part of one chunk.
+func newEXR(version uint32, attrs []byte) []byte {
+	dw := make([]byte, cDataWindowSize)
+	binary.LittleEndian.PutUint32(dw[8:], 9)
+	binary.LittleEndian.PutUint32(dw[12:], 39)
+	b := binary.LittleEndian.AppendUint32(nil, cMagic)
+	b = binary.LittleEndian.AppendUint32(b, version)
+	b = appendAttribute(b, "channels", "chlist", []byte{'Y', 0, 1, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0, 0})
+	b = appendAttribute(b, cAttrCompression, "compression", []byte{3})
+	b = appendAttribute(b, cAttrDataWindow, "box2i", dw)
+	lines := []int32{0, 16, 32}
+	if version&cFlagMultipart != 0 {
+		b = appendAttribute(b, cAttrChunkCount, "int", []byte{3, 0, 0, 0})
+		b = append(append(b, attrs...), 0)
+		b = appendAttribute(b, cAttrChunkCount, "int", []byte{1, 0, 0, 0})
+		lines = append(lines, 0)
+	} else {
+		b = append(b, attrs...)
+	}
+	b = append(b, 0)
+	if version&cFlagMultipart != 0 {
+		b = append(b, 0)
+	}
+	offset := uint64(len(b) + len(lines)*cOffsetSize)
+	for range lines {
+		b = binary.LittleEndian.AppendUint64(b, offset)
+		offset += 12
+	}
+	for _, y := range lines {
+		b = binary.LittleEndian.AppendUint32(b, uint32(y))
+		b = append(b, 4, 0, 0, 0, 0xDE, 0xAD, 0xBE, 0xEF)
+	}
+	return b
+}
+
+// chunkLines returns the first line of each chunk of the file `b` made by newEXR.
+func chunkLines(b []byte) []int32 {
+	f, err := readFile(bytes.NewReader(b))
+	if err != nil {
+		return nil
+	}
+	n, err := f.chunks()
+	if err != nil {
+		return nil
+	}
+	var lines []int32
+	for i := int64(0); i < n; i++ {
+		offset := binary.LittleEndian.Uint64(b[f.size+i*cOffsetSize:])
+		lines = append(lines, int32(binary.LittleEndian.Uint32(b[offset:])))
+	}
+	return lines
+}
+
+// newFPS returns a framesPerSecond attribute.
+func newFPS(num int32, den uint32) []byte {
+	v := binary.LittleEndian.AppendUint32(binary.LittleEndian.AppendUint32(nil, uint32(num)), den)
+	return appendAttribute(nil, cAttrFramesPerSec, cTypeRational, v)
+}
+
+func Describe(t *testing.T, msg ...string) (*require.Assertions,
+	*assert.Assertions) {
+
+	dispMsg := ""
+	if len(msg) != 0 {
+		dispMsg = msg[0]
+	}
+	name := strings.TrimPrefix(strings.TrimPrefix(t.Name(), "Test"), "_")
+	fmt.Printf("Test %d: %s %s\n", testCounter, name, dispMsg)
+	testCounter++
+	return require.New(t), assert.New(t)
+}
@@ -0,0 +1,343 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+// Package fcpxml converts the rational times of Final Cut Pro XML, e.g., `3600/1s` or `1001/30000s`, from
+// and to timecodes.  It extracts the sequences of an FCPXML document and the offsets, starts and
+// durations of their clips as timecode ranges.
+package fcpxml
+
+import (
+	"encoding/xml"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/wunderbarb/timecode"
+)
+
+var (
+	// ErrInvalidTime is returned when an FCPXML time string cannot be parsed.
+	ErrInvalidTime = errors.New("fcpxml: invalid time")
+	// ErrUnknownFormat is returned when an element refers to an undeclared format or has no frame duration.
+	ErrUnknownFormat = errors.New("fcpxml: unknown format")
+)
+
+const (
+	cDF     = "DF"
+	cBase   = 10
+	cBits64 = 64
+)
+
+// Time is a rational number of seconds `Num`/`Den`.
+type Time struct {
+	Num int64
+	Den int64
+}
+
+// ParseTime parses an FCPXML time string such as `0s`, `5s`, `3600/1s` or `1001/30000s`.
+func ParseTime(s string) (Time, error) {
+	v, ok := strings.CutSuffix(s, "s")
+	if !ok {
+		return Time{}, errors.Wrapf(ErrInvalidTime, "%q", s)
+	}
+	n, d, found := strings.Cut(v, "/")
+	num, err := strconv.ParseInt(n, cBase, cBits64)
+	if err != nil {
+		return Time{}, errors.Wrapf(ErrInvalidTime, "%q", s)
+	}
+	den := int64(1)
+	if found {
+		den, err = strconv.ParseInt(d, cBase, cBits64)
+		if err != nil || den <= 0 {
+			return Time{}, errors.Wrapf(ErrInvalidTime, "%q", s)
+		}
+	}
+	return Time{Num: num, Den: den}, nil
+}
+
+// String returns the time as FCPXML writes it, i.e., `Ns` when it is a whole number of seconds and
+// `N/Ds` otherwise.
+func (t Time) String() string {
+	r := t.rat()
+	if r.IsInt() {
+		return r.Num().String() + "s"
+	}
+	return r.Num().String() + "/" + r.Denom().String() + "s"
+}
+
+// Frames returns the number of frames of duration `frameDuration` in the time, rounded to the nearest frame.
+// The frame duration must be positive.
+func (t Time) Frames(frameDuration Time) (int, error) {
+	if frameDuration.rat().Sign() <= 0 {
+		return 0, errors.Wrapf(ErrInvalidTime, "frame duration %v", frameDuration)
+	}
+	q := new(big.Rat).Quo(t.rat(), frameDuration.rat())
+	// Adds one half and truncates, i.e., rounds to the nearest.
+	n := new(big.Rat).Add(q, big.NewRat(1, 2))
+	if q.Sign() < 0 {
+		n.Sub(q, big.NewRat(1, 2))
+	}
+	return int(new(big.Int).Quo(n.Num(), n.Denom()).Int64()), nil
+}
+
+// Timecode returns the timecode of the time at the frame duration `frameDuration`.
+func (t Time) Timecode(frameDuration Time, dropFrame bool) (*timecode.Timecode, error) {
+	r, err := NewRate(frameDuration, dropFrame)
+	if err != nil {
+		return nil, err
+	}
+	n, err := t.Frames(frameDuration)
+	if err != nil {
+		return nil, err
+	}
+	return timecode.NewFromRate(r, n)
+}
+
+// FromTimecode returns the time of the timecode `tc` at the frame duration `frameDuration`.
+func FromTimecode(tc timecode.Timecode, frameDuration Time) Time {
+	return FromFrames(tc.Frame(), frameDuration)
+}
+
+// FromFrames returns the time of `n` frames of duration `frameDuration`.
+func FromFrames(n int, frameDuration Time) Time {
+	return Time{Num: int64(n) * frameDuration.Num, Den: frameDuration.Den}
+}
+
+// NewRate returns the frame rate of the frame duration `frameDuration`, e.g., 29.97 FPS for `1001/30000s`.
+func NewRate(frameDuration Time, dropFrame bool) (timecode.Rate, error) {
+	if frameDuration.Num <= 0 || frameDuration.Den <= 0 {
+		return timecode.Rate{}, errors.Wrapf(timecode.ErrInvalidFPS, "frame duration %v", frameDuration)
+	}
+	r := timecode.Rate{FPS: float64(frameDuration.Den) / float64(frameDuration.Num), DropFrame: dropFrame}
+	if !r.Valid() {
+		return timecode.Rate{}, errors.Wrapf(timecode.ErrInvalidFPS, "frame duration %v", frameDuration)
+	}
+	return r, nil
+}
+
+// rat returns the time as a rational number.  A zero denominator gives 0.
+func (t Time) rat() *big.Rat {
+	if t.Den == 0 {
+		return new(big.Rat)
+	}
+	return big.NewRat(t.Num, t.Den)
+}
+
+// Clip is a clip of an FCPXML document, e.g., an `asset-clip`.
+type Clip struct {
+	// Element is the XML element of the clip, e.g., `asset-clip`, `clip`, `ref-clip` or `sync-clip`.
+	Element string
+	// Name is the name of the clip.
+	Name string
+	// Lane is the lane of a connected clip.  It is 0 for the clips of the primary storyline.
+	Lane int
+	// Offset is the position and duration of the clip in the timeline of its parent.  The offset of a
+	// connected clip is in the timeline of the clip it is connected to.
+	Offset timecode.Range
+	// Source is the start and duration of the clip in its media.
+	Source timecode.Range
+}
+
+// format is the frame duration and timecode format that apply to an element and its children.
+type format struct {
+	frameDuration Time
+	dropFrame     bool
+}
+
+// Sequence is a sequence of an FCPXML document with its clips.
+type Sequence struct {
+	// Project is the name of the project of the sequence.
+	Project string
+	// Range is the start, i.e., `tcStart`, and the duration of the sequence.
+	Range timecode.Range
+	// Clips are the clips of the sequence in document order.
+	Clips []Clip
+}
+
+// Read returns the sequences of the FCPXML document read from `r`.  The timecodes of a sequence and of
+// the offsets of its clips use the format of the sequence.  The source of a clip with its own `format`
+// uses the clip format unless it has no frame duration, e.g., for a still image.  The clips outside of a
+// sequence are ignored.
+func Read(r io.Reader) ([]Sequence, error) {
+	dec := xml.NewDecoder(r)
+	formats := map[string]Time{}
+	stack := []format{{}}
+	var (
+		seqs    []Sequence
+		project string
+		inSeq   int
+	)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return seqs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch el := tok.(type) {
+		case xml.StartElement:
+			attrs := attrMap(el.Attr)
+			if inSeq > 0 {
+				inSeq++
+			}
+			parent := stack[len(stack)-1]
+			own, err := ownFormat(parent, attrs, formats)
+			if err != nil {
+				return nil, err
+			}
+			stack = append(stack, own)
+			switch name := el.Name.Local; {
+			case name == "format":
+				if err := addFormat(formats, attrs); err != nil {
+					return nil, err
+				}
+			case name == "project":
+				project = attrs["name"]
+			case name == "sequence":
+				seq, err := newSequence(project, attrs, own)
+				if err != nil {
+					return nil, err
+				}
+				seqs = append(seqs, seq)
+				inSeq = 1
+			case inSeq > 0 && isClip(name):
+				c, err := newClip(name, attrs, parent, own)
+				if err != nil {
+					return nil, err
+				}
+				seqs[len(seqs)-1].Clips = append(seqs[len(seqs)-1].Clips, c)
+			}
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+			if inSeq > 0 {
+				inSeq--
+			}
+		}
+	}
+}
+
+// addFormat records the frame duration of the `format` element with the attributes `attrs`.  A format
+// without frame duration, e.g., of a still image, is recorded with a zero frame duration.
+func addFormat(formats map[string]Time, attrs map[string]string) error {
+	fd, ok := attrs["frameDuration"]
+	if !ok {
+		formats[attrs["id"]] = Time{}
+		return nil
+	}
+	t, err := ParseTime(fd)
+	if err != nil {
+		return err
+	}
+	formats[attrs["id"]] = t
+	return nil
+}
+
+func newSequence(project string, attrs map[string]string, f format) (Sequence, error) {
+	times, err := parseTimes(attrs, "tcStart", "duration")
+	if err != nil {
+		return Sequence{}, errors.Wrapf(err, "sequence of %q", project)
+	}
+	r, err := newRange(times[0], times[1], f)
+	if err != nil {
+		return Sequence{}, errors.Wrapf(err, "sequence of %q", project)
+	}
+	return Sequence{Project: project, Range: r}, nil
+}
+
+// ownFormat returns the format of the element with the attributes `attrs` inside an element of format
+// `parent`.  A format without frame duration, e.g., of a still image, keeps the frame duration of
+// `parent`.
+func ownFormat(parent format, attrs map[string]string, formats map[string]Time) (format, error) {
+	f := parent
+	if id, ok := attrs["format"]; ok {
+		fd, ok := formats[id]
+		if !ok {
+			return format{}, errors.Wrapf(ErrUnknownFormat, "%q", id)
+		}
+		if fd.Den != 0 {
+			f.frameDuration = fd
+			f.dropFrame = false
+		}
+	}
+	if tf, ok := attrs["tcFormat"]; ok {
+		f.dropFrame = tf == cDF
+	}
+	return f, nil
+}
+
+func newClip(element string, attrs map[string]string, parent format, own format) (Clip, error) {
+	c := Clip{Element: element, Name: attrs["name"]}
+	if lane, ok := attrs["lane"]; ok {
+		n, err := strconv.Atoi(lane)
+		if err != nil {
+			return Clip{}, errors.Wrapf(ErrInvalidTime, "lane %q", lane)
+		}
+		c.Lane = n
+	}
+	times, err := parseTimes(attrs, "offset", "start", "duration")
+	if err != nil {
+		return Clip{}, errors.Wrapf(err, "%s %q", element, c.Name)
+	}
+	c.Offset, err = newRange(times[0], times[2], parent)
+	if err != nil {
+		return Clip{}, errors.Wrapf(err, "%s %q", element, c.Name)
+	}
+	c.Source, err = newRange(times[1], times[2], own)
+	if err != nil {
+		return Clip{}, errors.Wrapf(err, "%s %q", element, c.Name)
+	}
+	return c, nil
+}
+
+// parseTimes returns the times of the attributes `names`.  A missing attribute is 0s.
+func parseTimes(attrs map[string]string, names ...string) ([]Time, error) {
+	times := make([]Time, len(names))
+	for i, name := range names {
+		times[i] = Time{Num: 0, Den: 1}
+		if v, ok := attrs[name]; ok {
+			t, err := ParseTime(v)
+			if err != nil {
+				return nil, err
+			}
+			times[i] = t
+		}
+	}
+	return times, nil
+}
+
+func newRange(start Time, duration Time, f format) (timecode.Range, error) {
+	if f.frameDuration.Den == 0 {
+		return timecode.Range{}, errors.Wrapf(ErrUnknownFormat, "no frame duration")
+	}
+	tc, err := start.Timecode(f.frameDuration, f.dropFrame)
+	if err != nil {
+		return timecode.Range{}, err
+	}
+	n, err := duration.Frames(f.frameDuration)
+	if err != nil {
+		return timecode.Range{}, err
+	}
+	return timecode.Range{Start: *tc, Duration: n}, nil
+}
+
+func isClip(name string) bool {
+	switch name {
+	case "asset-clip", "clip", "ref-clip", "sync-clip", "mc-clip":
+		return true
+	default:
+		return false
+	}
+}
+
+func attrMap(attrs []xml.Attr) map[string]string {
+	m := make(map[string]string, len(attrs))
+	for _, a := range attrs {
+		m[a.Name.Local] = a.Value
+	}
+	return m
+}
@@ -0,0 +1,165 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package fcpxml
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wunderbarb/timecode"
+)
+
+var testCounter int
+
+func TestParseTime(t *testing.T) {
+	require, assert := Describe(t)
+
+	tests := []struct {
+		s          string
+		exp        Time
+		expString  string
+		expSuccess bool
+	}{
+		{"0s", Time{0, 1}, "0s", true},
+		{"5s", Time{5, 1}, "5s", true},
+		{"3600/1s", Time{3600, 1}, "3600s", true},
+		{"1001/30000s", Time{1001, 30000}, "1001/30000s", true},
+		{"2002/60000s", Time{2002, 60000}, "1001/30000s", true},
+		{"-1/25s", Time{-1, 25}, "-1/25s", true},
+		{"1001/30000", Time{}, "", false},
+		{"1001/0s", Time{}, "", false},
+		{"a/25s", Time{}, "", false},
+		{"1.5s", Time{}, "", false},
+	}
+	for i, tt := range tests {
+		tm, err := ParseTime(tt.s)
+		require.Equal(tt.expSuccess, err == nil, "sample %d", i+1)
+		if err == nil {
+			assert.Equal(tt.exp, tm, "sample %d", i+1)
+			assert.Equal(tt.expString, tm.String(), "sample %d", i+1)
+		} else {
+			assert.ErrorIs(err, ErrInvalidTime, "sample %d", i+1)
+		}
+	}
+}
+
+func TestTime_Timecode(t *testing.T) {
+	require, assert := Describe(t)
+
+	fd, _ := ParseTime("1001/30000s")
+	tm, _ := ParseTime("3600s")
+	tc, err := tm.Timecode(fd, true)
+	require.NoError(err)
+	assert.Equal("01:00:00;00", tc.String())
+	tm, _ = ParseTime("108108/30000s")
+	tc, err = tm.Timecode(fd, true)
+	require.NoError(err)
+	assert.Equal("00:00:03;18", tc.String())
+	n, err := tm.Frames(fd)
+	require.NoError(err)
+	assert.Equal(108, n)
+	for i, fd0 := range []string{"0s", "0/1s", "-1/25s"} {
+		d, _ := ParseTime(fd0)
+		_, err = tm.Frames(d)
+		assert.ErrorIs(err, ErrInvalidTime, "sample %d", i+1)
+	}
+	_, err = tm.Frames(Time{})
+	assert.ErrorIs(err, ErrInvalidTime)
+	assert.Equal("9009/2500s", FromTimecode(*tc, fd).String())
+
+	fd25, _ := ParseTime("1/25s")
+	tc, err = Time{Num: 3600, Den: 1}.Timecode(fd25, false)
+	require.NoError(err)
+	assert.Equal("01:00:00:00", tc.String())
+	assert.Equal("3600s", FromTimecode(*tc, fd25).String())
+	_, err = tm.Timecode(fd25, true)
+	assert.ErrorIs(err, timecode.ErrInvalidFPS)
+	_, err = NewRate(Time{}, false)
+	assert.ErrorIs(err, timecode.ErrInvalidFPS)
+	r, err := NewRate(fd, true)
+	require.NoError(err)
+	assert.Equal(timecode.Rate2997DF, r)
+}
+
+func TestRead(t *testing.T) {
+	require, assert := Describe(t)
+
+	f, err := os.Open("testdata/project.fcpxml")
+	require.NoError(err)
+	defer f.Close()
+	seqs, err := Read(f)
+	require.NoError(err)
+	require.Len(seqs, 1)
+	assert.Equal("Scene 12", seqs[0].Project)
+	assert.Equal("01:00:00;00-01:00:03;00", seqs[0].Range.String())
+	clips := seqs[0].Clips
+	require.Len(clips, 3)
+
+	c := clips[0]
+	assert.Equal("asset-clip", c.Element)
+	assert.Equal("B002C010", c.Name)
+	assert.Equal(0, c.Lane)
+	assert.Equal("01:00:00;00-01:00:01;00", c.Offset.String())
+	assert.Equal("00:00:03;18-00:00:04;18", c.Source.String())
+
+	c = clips[1]
+	assert.Equal("A001C003", c.Name)
+	assert.Equal(1, c.Lane)
+	assert.Equal("00:00:03;18-00:00:04;18", c.Offset.String())
+	assert.Equal("01:00:00:00-01:00:01:00", c.Source.String())
+	assert.Equal(timecode.Rate25, c.Source.Start.Rate())
+
+	assert.Equal("clip", clips[2].Element)
+	assert.Equal(30, clips[2].Offset.Duration)
+
+	seqs, err = Read(strings.NewReader(`<fcpxml><clip offset="1.5s"/></fcpxml>`))
+	require.NoError(err)
+	assert.Empty(seqs)
+	_, err = Read(strings.NewReader(`<fcpxml><sequence format="r9"/></fcpxml>`))
+	assert.ErrorIs(err, ErrUnknownFormat)
+	_, err = Read(strings.NewReader(`<fcpxml><sequence/></fcpxml>`))
+	assert.ErrorIs(err, ErrUnknownFormat)
+	// A still image format has no frame duration.
+	seqs, err = Read(strings.NewReader(`<fcpxml><resources><format id="r1" frameDuration="1/25s"/>` +
+		`<format id="r2" name="FFVideoFormatRateUndefined"/><asset id="a1" format="r2"/></resources>` +
+		`<project name="P"><sequence format="r1" duration="10s"><spine>` +
+		`<asset-clip ref="a1" format="r2" offset="1s" duration="2s"/></spine></sequence></project></fcpxml>`))
+	require.NoError(err)
+	require.Len(seqs, 1)
+	require.Len(seqs[0].Clips, 1)
+	assert.Equal("00:00:01:00-00:00:03:00", seqs[0].Clips[0].Offset.String())
+	assert.Equal("00:00:00:00-00:00:02:00", seqs[0].Clips[0].Source.String())
+	_, err = Read(strings.NewReader(`<fcpxml><format id="r2"/><sequence format="r2"/></fcpxml>`))
+	assert.ErrorIs(err, ErrUnknownFormat)
+	_, err = Read(strings.NewReader(`<fcpxml><format id="r0" frameDuration="0s"/><sequence format="r0"/></fcpxml>`))
+	assert.Error(err)
+	_, err = Read(strings.NewReader(`<fcpxml><format id="r1" frameDuration="1/25s"/>` +
+		`<sequence format="r1"><spine><clip offset="1.5s"/></spine></sequence></fcpxml>`))
+	assert.ErrorIs(err, ErrInvalidTime)
+	_, err = Read(strings.NewReader(`<fcpxml><format id="r1" frameDuration="1/25s"/>` +
+		`<sequence format="r1" tcFormat="DF"/></fcpxml>`))
+	assert.ErrorIs(err, timecode.ErrInvalidFPS)
+}
+
+// Describe displays the rank of the test, the name of the function
+// and its optional description provided by 'msg'.  It initializes an assert
+// and a require function and returns them.
+func Describe(t *testing.T, msg ...string) (*require.Assertions,
+	*assert.Assertions) {
+
+	dispMsg := ""
+	if len(msg) != 0 {
+		dispMsg = msg[0]
+	}
+	name := strings.TrimPrefix(strings.TrimPrefix(t.Name(), "Test"), "_")
+	fmt.Printf("Test %d: %s %s\n", testCounter, name, dispMsg)
+	testCounter++
+	return require.New(t), assert.New(t)
+}
@@ -0,0 +1,32 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+// NewFromFields initializes a Timecode structure with the rate `r` at the label with the hours `h`, the
+// minutes `m`, the seconds `s` and the frames `f`.  The label must be within 24 hours and not dropped.
+func NewFromFields(r Rate, h int, m int, s int, f int) (*Timecode, error) {
+	if !r.Valid() {
+		return nil, ErrInvalidFPS
+	}
+	if h < 0 || h >= 24 || m < 0 || m >= cNumSec || s < 0 || s >= cNumSec || f < 0 {
+		return nil, ErrInvalidTimeCode
+	}
+	frame, err := newFrameTable(r).frame(h, m, s, f)
+	if err != nil {
+		return nil, err
+	}
+	return &Timecode{fps: r.FPS, dropFrame: r.DropFrame, currentFrame: frame}, nil
+}
+
+// Fields returns the hours, minutes, seconds and frames of the label of the timecode wrapped at 24 hours.
+func (t *Timecode) Fields() (h int, m int, s int, f int) {
+	ft := t.table()
+	return ft.fields(ft.wrap(t.currentFrame))
+}
+
+// wrap returns the frame `frame` wrapped to the labels of 24 hours.
+func (ft frameTable) wrap(frame int) int {
+	return (frame%ft.framesPerDay + ft.framesPerDay) % ft.framesPerDay
+}
@@ -0,0 +1,59 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"testing"
+)
+
+func TestNewFromFields(t *testing.T) {
+	require, assert := Describe(t)
+
+	tests := []struct {
+		r          Rate
+		h, m, s, f int
+		exp        string
+		expSuccess bool
+	}{
+		{Rate25, 12, 34, 56, 22, "12:34:56:22", true},
+		{Rate2997DF, 1, 0, 0, 0, "01:00:00;00", true},
+		{Rate2997DF, 0, 1, 0, 2, "00:01:00;02", true},
+		{Rate2997DF, 0, 1, 0, 1, "", false},
+		{Rate25, 0, 0, 0, 25, "", false},
+		{Rate25, 24, 0, 0, 0, "", false},
+		{Rate25, 0, 60, 0, 0, "", false},
+		{Rate25, 0, 0, -1, 0, "", false},
+		{Rate{FPS: 25, DropFrame: true}, 0, 0, 0, 0, "", false},
+	}
+	for i, tt := range tests {
+		tc, err := NewFromFields(tt.r, tt.h, tt.m, tt.s, tt.f)
+		require.Equal(tt.expSuccess, err == nil, "sample %d", i+1)
+		if err == nil {
+			assert.Equal(tt.exp, tc.String(), "sample %d", i+1)
+		}
+	}
+}
+
+func TestTimecode_Fields(t *testing.T) {
+	_, assert := Describe(t)
+
+	tests := []struct {
+		r          Rate
+		frame      int
+		h, m, s, f int
+	}{
+		{Rate25, 0, 0, 0, 0, 0},
+		{Rate25, 90000*24 + 26, 0, 0, 1, 1},
+		{Rate2997DF, 1800, 0, 1, 0, 2},
+		{Rate2997DF, 2589408 + 1, 0, 0, 0, 1},
+		{Rate24, -1, 23, 59, 59, 23},
+	}
+	for i, tt := range tests {
+		tc, _ := NewFromRate(tt.r, 0)
+		tc.Offset(tt.frame)
+		h, m, s, f := tc.Fields()
+		assert.Equal([]int{tt.h, tt.m, tt.s, tt.f}, []int{h, m, s, f}, "sample %d", i+1)
+	}
+}
@@ -0,0 +1,45 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// Format implements fmt.Formatter.  The verbs are:
+//
+//	%s, %v   the timecode, e.g., 01:00:00;00
+//	%+v      the timecode with its rate, e.g., 01:00:00;00@29.97DF
+//	%q       the quoted timecode, e.g., "01:00:00;00"
+//	%d       the frame count, i.e., Frame()
+//
+// The width and flags apply as for a string or an integer.
+func (t Timecode) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		s := t.String()
+		if f.Flag('+') {
+			s += "@" + t.Rate().String()
+		}
+		_, _ = fmt.Fprintf(f, fmt.FormatString(f, 's'), s)
+	case 's', 'q':
+		_, _ = fmt.Fprintf(f, fmt.FormatString(f, verb), t.String())
+	case 'd':
+		_, _ = fmt.Fprintf(f, fmt.FormatString(f, verb), t.currentFrame)
+	default:
+		_, _ = fmt.Fprintf(f, "%%!%c(timecode.Timecode=%s)", verb, t.String())
+	}
+}
+
+// LogValue implements slog.LogValuer.  The timecode is logged as a group with its frame count,
+// its string and its rate.
+func (t Timecode) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int("frame", t.currentFrame),
+		slog.String("timecode", t.String()),
+		slog.String("rate", t.Rate().String()),
+	)
+}
@@ -0,0 +1,60 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"testing"
+)
+
+func TestTimecode_Format(t *testing.T) {
+	_, assert := Describe(t)
+
+	t1, _ := NewWithDropFrameFromString("01:00:00;00")
+	t2, _ := NewFromFrame(FPS23976fps, 25)
+	tests := []struct {
+		format string
+		tc     *Timecode
+		expRes string
+	}{
+		{"%v", t1, "01:00:00;00"},
+		{"%s", t1, "01:00:00;00"},
+		{"%+v", t1, "01:00:00;00@29.97DF"},
+		{"%+v", t2, "00:00:01:01@23.976"},
+		{"%d", t1, "107892"},
+		{"%08d", t2, "00000025"},
+		{"%q", t2, `"00:00:01:01"`},
+		{"%13s|", t2, "  00:00:01:01|"},
+		{"%-13v|", t2, "00:00:01:01  |"},
+		{"%x", t2, "%!x(timecode.Timecode=00:00:01:01)"},
+	}
+	for i, tt := range tests {
+		assert.Equal(tt.expRes, fmt.Sprintf(tt.format, tt.tc), "sample %d", i+1)
+		assert.Equal(tt.expRes, fmt.Sprintf(tt.format, *tt.tc), "sample %d", i+1)
+	}
+}
+
+func TestTimecode_LogValue(t *testing.T) {
+	_, assert := Describe(t)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	tc, _ := NewWithDropFrameFromString("01:00:00;00")
+	for i, v := range []any{tc, *tc} {
+		buf.Reset()
+		logger.Info("ingest", "tc", v)
+		assert.JSONEq(`{"level":"INFO","msg":"ingest",
+		"tc":{"frame":107892,"timecode":"01:00:00;00","rate":"29.97DF"}}`, buf.String(), "sample %d", i+1)
+	}
+}
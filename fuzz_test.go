@@ -0,0 +1,188 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"errors"
+	"testing"
+)
+
+// _supportedRates are the frame rates and drop frame modes exercised by the property tests.
+var _supportedRates = []Rate{Rate23976, Rate24, Rate25, Rate2997, Rate2997DF, Rate30}
+
+// supportedRate returns the supported rate selected by `i`.
+func supportedRate(i uint8) Rate {
+	return _supportedRates[int(i)%len(_supportedRates)]
+}
+
+// checkRoundTrip verifies that the label of the frame `frame` at the rate `r` parses back to `frame` and
+// is never dropped in drop frame.
+func checkRoundTrip(t *testing.T, r Rate, frame int) {
+	tc, err := NewFromRate(r, frame)
+	if err != nil {
+		t.Fatalf("%v frame %d: %v", r, frame, err)
+	}
+	s := tc.String()
+	tc1, _ := NewFromRate(r, 0)
+	if err := tc1.Parse(s); err != nil {
+		t.Fatalf("%v frame %d: %q does not parse: %v", r, frame, s, err)
+	}
+	if tc1.Frame() != frame {
+		t.Fatalf("%v frame %d: %q parses to frame %d", r, frame, s, tc1.Frame())
+	}
+	if r.DropFrame {
+		_, m1, s1, f := tc.fields()
+		if isDropped(m1, s1, f) {
+			t.Fatalf("%v frame %d: dropped label %q", r, frame, s)
+		}
+	}
+}
+
+func TestProperty_RoundTrip(t *testing.T) {
+	Describe(t)
+
+	for _, r := range _supportedRates {
+		tc, _ := NewFromRate(r, 0)
+		step := 1
+		if testing.Short() || !r.DropFrame {
+			step = 7
+		}
+		for f := 0; f < tc.framesPerDay(); f += step {
+			checkRoundTrip(t, r, f)
+		}
+	}
+}
+
+func TestProperty_Monotonic(t *testing.T) {
+	Describe(t)
+
+	for _, r := range _supportedRates {
+		prevMs := -1
+		prevFrame := -1
+		for f := 0; f < 10*cNumSec*cast2Round(r.FPS); f++ {
+			tc, _ := NewFromRate(r, f)
+			ms := tc.Milliseconds()
+			if ms <= prevMs {
+				t.Fatalf("%v frame %d: %d ms after %d ms", r, f, ms, prevMs)
+			}
+			prevMs = ms
+			tc1, _ := NewFromMilliseconds(r.FPS, f)
+			if tc1.Frame() < prevFrame {
+				t.Fatalf("%v %d ms: frame %d after frame %d", r, f, tc1.Frame(), prevFrame)
+			}
+			prevFrame = tc1.Frame()
+		}
+	}
+}
+
+func FuzzRoundTrip(f *testing.F) {
+	f.Add(uint8(0), uint32(0))
+	f.Add(uint8(4), uint32(1799))
+	f.Add(uint8(4), uint32(17982))
+	f.Fuzz(func(t *testing.T, ri uint8, frame uint32) {
+		r := supportedRate(ri)
+		tc, _ := NewFromRate(r, 0)
+		checkRoundTrip(t, r, int(frame)%tc.framesPerDay())
+	})
+}
+
+func FuzzParse(f *testing.F) {
+	f.Add(uint8(2), "12:34:56:22")
+	f.Add(uint8(4), "00:01:00;02")
+	f.Add(uint8(4), "00:01:00;00")
+	f.Add(uint8(1), "99:59:59:23")
+	f.Fuzz(func(t *testing.T, ri uint8, s string) {
+		r := supportedRate(ri)
+		tc, _ := NewFromRate(r, 0)
+		err := tc.Parse(s)
+		tcb, _ := NewFromRate(r, 0)
+		errb := tcb.ParseBytes([]byte(s))
+		if (err == nil) != (errb == nil) || tc.Frame() != tcb.Frame() {
+			t.Fatalf("%v %q: Parse %d %v and ParseBytes %d %v differ", r, s, tc.Frame(), err, tcb.Frame(), errb)
+		}
+		if err != nil {
+			var pe *ParseError
+			if !errors.As(err, &pe) || !errors.Is(err, ErrInvalidTimeCode) {
+				t.Fatalf("%v %q: unexpected error %v", r, s, err)
+			}
+			return
+		}
+		checkRoundTrip(t, r, tc.Frame()%tc.framesPerDay())
+	})
+}
+
+func FuzzAddSubtract(f *testing.F) {
+	f.Add(uint8(0), uint32(0), uint32(0))
+	f.Add(uint8(3), uint32(2589409), uint32(1))
+	f.Add(uint8(4), uint32(1800), uint32(2589407))
+	f.Fuzz(func(t *testing.T, ri uint8, a uint32, b uint32) {
+		r := supportedRate(ri)
+		ta, _ := NewFromRate(r, 0)
+		modulo := ta.modulo24H()
+		ta.SetFrame(int(a) % modulo)
+		tb, _ := NewFromRate(r, int(b)%modulo)
+		tc := Clone(ta)
+		if err := tc.Add(*tb); err != nil {
+			t.Fatal(err)
+		}
+		if tc.Frame() < 0 || tc.Frame() >= modulo {
+			t.Fatalf("%v %d + %d = %d out of 24 hours", r, ta.Frame(), tb.Frame(), tc.Frame())
+		}
+		if err := tc.Subtract(*tb); err != nil {
+			t.Fatal(err)
+		}
+		if !tc.Equal(*ta) {
+			t.Fatalf("%v %d + %d - %d = %d", r, ta.Frame(), tb.Frame(), tb.Frame(), tc.Frame())
+		}
+	})
+}
+
+func FuzzMilliseconds(f *testing.F) {
+	f.Add(uint8(0), uint32(0))
+	f.Add(uint8(3), uint32(30))
+	f.Add(uint8(0), uint32(2071484))
+	f.Fuzz(func(t *testing.T, ri uint8, frame uint32) {
+		r := supportedRate(ri)
+		tc, err := NewFromRate(r, int(frame))
+		if err != nil {
+			t.Skip("frame out of int range")
+		}
+		tn, err := NewFromRate(r, int(frame)+1)
+		if err != nil {
+			t.Skip("frame out of int range")
+		}
+		if tc.Milliseconds() >= tn.Milliseconds() {
+			t.Fatalf("%v frame %d: %d ms not before %d ms", r, frame, tc.Milliseconds(), tn.Milliseconds())
+		}
+		tm, _ := NewFromMilliseconds(r.FPS, tc.Milliseconds())
+		if tm.Frame() != tc.Frame() {
+			t.Fatalf("%v frame %d: %d ms converts back to frame %d", r, frame, tc.Milliseconds(), tm.Frame())
+		}
+	})
+}
+
+func FuzzSamples(f *testing.F) {
+	f.Add(uint8(4), uint32(1), true)
+	f.Add(uint8(0), uint32(2073599), false)
+	f.Fuzz(func(t *testing.T, ri uint8, frame uint32, hd bool) {
+		r := supportedRate(ri)
+		sampleRate := 48000
+		if hd {
+			sampleRate = 96000
+		}
+		tc, _ := NewFromRate(r, int(frame))
+		tn, _ := NewFromRate(r, int(frame)+1)
+		s := tc.Samples(sampleRate)
+		if s >= tn.Samples(sampleRate) {
+			t.Fatalf("%v frame %d: sample %d not before %d", r, frame, s, tn.Samples(sampleRate))
+		}
+		for _, x := range []int64{s, tn.Samples(sampleRate) - 1} {
+			ts, _ := NewFromSamples(r, x, sampleRate)
+			if ts.Frame() != tc.Frame() {
+				t.Fatalf("%v frame %d: sample %d converts back to frame %d", r, frame, x, ts.Frame())
+			}
+		}
+	})
+}
@@ -0,0 +1,75 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"math/rand"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNilSource is returned when a Generator has no random source.
+var ErrNilSource = errors.New("nil random source")
+
+// Generator generates random timecodes and ranges at a given frame rate.  A Generator seeded with
+// the same source generates the same sequence.  It is not safe for concurrent use; parallel tests
+// should use one Generator each.
+type Generator struct {
+	rng   *rand.Rand
+	rate  Rate
+	first int
+	last  int
+}
+
+// NewGenerator returns a Generator drawing from the source `src` at the rate `r`.  Its bounds are the
+// 24 hours of timecode labels, i.e., 00:00:00:00 to 23:59:59:ff.
+func NewGenerator(src rand.Source, r Rate) (*Generator, error) {
+	if !r.Valid() {
+		return nil, ErrInvalidFPS
+	}
+	if src == nil {
+		return nil, ErrNilSource
+	}
+	tc := Timecode{fps: r.FPS, dropFrame: r.DropFrame}
+	return &Generator{rng: rand.New(src), rate: r, first: 0, last: tc.framesPerDay() - 1}, nil //nolint:gosec
+}
+
+// SetBounds restricts the generated frames to `first` to `last` included.
+func (g *Generator) SetBounds(first int, last int) error {
+	if first < 0 || last < first {
+		return errors.Wrapf(ErrInvalidRange, "bounds %d to %d", first, last)
+	}
+	g.first = first
+	g.last = last
+	return nil
+}
+
+// Rate returns the frame rate and drop frame of the generated timecodes.
+func (g *Generator) Rate() Rate {
+	return g.rate
+}
+
+// Timecode returns a random timecode within the bounds.
+func (g *Generator) Timecode() Timecode {
+	return Timecode{fps: g.rate.FPS, dropFrame: g.rate.DropFrame, currentFrame: g.frame(g.first, g.last)}
+}
+
+// Range returns a random range within the bounds.  Its duration is between 1 and `maxDuration` frames.
+// It is shorter if the range would exceed the bounds.
+func (g *Generator) Range(maxDuration int) Range {
+	if maxDuration < 1 {
+		maxDuration = 1
+	}
+	start := g.Timecode()
+	if room := g.last - start.currentFrame + 1; maxDuration > room {
+		maxDuration = room
+	}
+	return Range{Start: start, Duration: g.frame(1, maxDuration)}
+}
+
+// frame returns a random frame between `first` and `last` included.
+func (g *Generator) frame(first int, last int) int {
+	return first + g.rng.Intn(last-first+1)
+}
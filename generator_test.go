@@ -0,0 +1,68 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestGenerator_Timecode(t *testing.T) {
+	require, assert := Describe(t)
+
+	g1, err := NewGenerator(rand.NewSource(42), Rate2997DF)
+	require.NoError(err)
+	g2, _ := NewGenerator(rand.NewSource(42), Rate2997DF)
+	for i := 0; i < 100; i++ {
+		tc1, tc2 := g1.Timecode(), g2.Timecode()
+		require.True(tc1.Equal(tc2), "sample %d", i+1)
+		assert.True(tc1.Rate().DropFrame, "sample %d", i+1)
+		assert.Less(tc1.Frame(), tc1.framesPerDay(), "sample %d", i+1)
+	}
+	assert.Equal(Rate2997DF, g1.Rate())
+
+	require.NoError(g1.SetBounds(100, 109))
+	for i := 0; i < 100; i++ {
+		tc := g1.Timecode()
+		assert.GreaterOrEqual(tc.Frame(), 100, "sample %d", i+1)
+		assert.LessOrEqual(tc.Frame(), 109, "sample %d", i+1)
+	}
+	assert.ErrorIs(g1.SetBounds(10, 9), ErrInvalidRange)
+	assert.ErrorIs(g1.SetBounds(-1, 9), ErrInvalidRange)
+	_, err = NewGenerator(rand.NewSource(1), Rate{FPS: cFPS25, DropFrame: true})
+	assert.ErrorIs(err, ErrInvalidFPS)
+	_, err = NewGenerator(nil, Rate25)
+	assert.ErrorIs(err, ErrNilSource)
+}
+
+func TestGenerator_Range(t *testing.T) {
+	require, assert := Describe(t)
+
+	g, _ := NewGenerator(rand.NewSource(7), Rate25)
+	require.NoError(g.SetBounds(0, 999))
+	for i := 0; i < 1000; i++ {
+		r := g.Range(50)
+		assert.GreaterOrEqual(r.Duration, 1, "sample %d", i+1)
+		assert.LessOrEqual(r.Duration, 50, "sample %d", i+1)
+		assert.LessOrEqual(r.Start.Frame()+r.Duration, 1000, "sample %d", i+1)
+	}
+	assert.Equal(1, g.Range(0).Duration)
+}
+
+func TestGenerator_Parallel(t *testing.T) {
+	Describe(t)
+
+	for i := 0; i < 4; i++ {
+		seed := int64(i)
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			g, _ := NewGenerator(rand.NewSource(seed), Rate24)
+			for j := 0; j < 1000; j++ {
+				_ = g.Timecode()
+				_ = RandomTimecode(cFPS24)
+			}
+		})
+	}
+}
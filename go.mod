@@ -4,7 +4,6 @@ go 1.21.5
 
 require (
 	github.com/pkg/errors v0.9.1
-	github.com/shopspring/decimal v1.3.1
 	github.com/stretchr/testify v1.8.4
 )
 
@@ -0,0 +1,217 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+// Package imgseq maps the file names of image sequences such as DPX or OpenEXR to timecodes, e.g.,
+// `shot_010.086400.exr` is the frame 86400, i.e., 01:00:00:00 at 24 FPS.
+//
+// A pattern describes the file names of a sequence with either a printf verb, e.g., `shot_010.%06d.exr`,
+// or a run of hashes with one hash per digit, e.g., `shot_010.######.exr`.
+package imgseq
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/wunderbarb/timecode"
+)
+
+var (
+	// ErrInvalidPattern is returned when a pattern has no or several frame number placeholders.
+	ErrInvalidPattern = errors.New("imgseq: invalid pattern")
+	// ErrNoMatch is returned when a file name does not match the pattern.
+	ErrNoMatch = errors.New("imgseq: file name does not match the pattern")
+)
+
+// Pattern is the file name pattern of an image sequence.
+type Pattern struct {
+	// Prefix is the part of the file name before the frame number.
+	Prefix string
+	// Suffix is the part of the file name after the frame number, usually the extension.
+	Suffix string
+	// Padding is the minimal number of digits of the frame number.  Zero means no padding.
+	Padding int
+}
+
+// ParsePattern parses a pattern such as `shot_010.%06d.exr`, `shot_010.%d.exr` or `shot_010.######.exr`.
+func ParsePattern(p string) (Pattern, error) {
+	if i := strings.Index(p, "%"); i >= 0 {
+		j := strings.Index(p[i:], "d")
+		if j < 0 {
+			return Pattern{}, errors.Wrapf(ErrInvalidPattern, "%q", p)
+		}
+		pat := Pattern{Prefix: p[:i], Suffix: p[i+j+1:]}
+		if spec := p[i+1 : i+j]; spec != "" {
+			n, err := strconv.Atoi(spec)
+			if err != nil || !strings.HasPrefix(spec, "0") || n <= 0 {
+				return Pattern{}, errors.Wrapf(ErrInvalidPattern, "%q", p)
+			}
+			pat.Padding = n
+		}
+		if strings.ContainsAny(pat.Prefix+pat.Suffix, "%#") {
+			return Pattern{}, errors.Wrapf(ErrInvalidPattern, "%q", p)
+		}
+		return pat, nil
+	}
+	i := strings.Index(p, "#")
+	if i < 0 {
+		return Pattern{}, errors.Wrapf(ErrInvalidPattern, "%q", p)
+	}
+	j := i
+	for j < len(p) && p[j] == '#' {
+		j++
+	}
+	pat := Pattern{Prefix: p[:i], Suffix: p[j:], Padding: j - i}
+	if strings.ContainsAny(pat.Suffix, "%#") {
+		return Pattern{}, errors.Wrapf(ErrInvalidPattern, "%q", p)
+	}
+	return pat, nil
+}
+
+// ParseName splits the file name `name` of an image sequence into its pattern and its frame number.
+// The frame number is the last run of digits of the base name before the extension.  The padding is
+// the number of its digits.  The directory, if any, is part of the prefix.
+func ParseName(name string) (Pattern, int, error) {
+	ext := path.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	end := len(stem)
+	for end > 0 && !isDigit(stem[end-1]) {
+		end--
+	}
+	start := end
+	for start > 0 && isDigit(stem[start-1]) {
+		start--
+	}
+	if start == end || strings.Contains(stem[start:], "/") {
+		return Pattern{}, 0, errors.Wrapf(ErrNoMatch, "%q", name)
+	}
+	n, err := strconv.Atoi(stem[start:end])
+	if err != nil {
+		return Pattern{}, 0, errors.Wrapf(ErrNoMatch, "%q", name)
+	}
+	return Pattern{Prefix: stem[:start], Suffix: stem[end:] + ext, Padding: end - start}, n, nil
+}
+
+// String returns the pattern with a printf verb, e.g., `shot_010.%06d.exr`.
+func (p Pattern) String() string {
+	verb := "%d"
+	if p.Padding > 0 {
+		verb = fmt.Sprintf("%%0%dd", p.Padding)
+	}
+	return p.Prefix + verb + p.Suffix
+}
+
+// Name returns the file name of the frame number `n`.
+func (p Pattern) Name(n int) string {
+	return fmt.Sprintf("%s%0*d%s", p.Prefix, p.Padding, n, p.Suffix)
+}
+
+// Frame returns the frame number of the file name `name`.  The frame number must have the padding of
+// the pattern unless it needs more digits.
+func (p Pattern) Frame(name string) (int, error) {
+	if !strings.HasPrefix(name, p.Prefix) || !strings.HasSuffix(name, p.Suffix) ||
+		len(name) <= len(p.Prefix)+len(p.Suffix) {
+		return 0, errors.Wrapf(ErrNoMatch, "%q", name)
+	}
+	digits := name[len(p.Prefix) : len(name)-len(p.Suffix)]
+	for i := 0; i < len(digits); i++ {
+		if !isDigit(digits[i]) {
+			return 0, errors.Wrapf(ErrNoMatch, "%q", name)
+		}
+	}
+	n, err := strconv.Atoi(digits)
+	if err != nil || p.Name(n) != name {
+		return 0, errors.Wrapf(ErrNoMatch, "%q", name)
+	}
+	return n, nil
+}
+
+// Frames returns the sorted frame numbers of the file names of `names` that match the pattern.  The
+// other names are ignored, so `names` may be a whole directory listing.
+func (p Pattern) Frames(names []string) []int {
+	var frames []int
+	for _, name := range names {
+		if n, err := p.Frame(name); err == nil {
+			frames = append(frames, n)
+		}
+	}
+	sort.Ints(frames)
+	return frames
+}
+
+// Sequence maps the file names of a pattern to timecodes.  The file with the frame number `n` is the
+// timecode frame `n + Offset` at the rate `Rate`.
+type Sequence struct {
+	Pattern Pattern
+	Rate    timecode.Rate
+	Offset  int
+}
+
+// Timecode returns the timecode of the file name `name`.
+func (s Sequence) Timecode(name string) (*timecode.Timecode, error) {
+	n, err := s.Pattern.Frame(name)
+	if err != nil {
+		return nil, err
+	}
+	return timecode.NewFromRate(s.Rate, n+s.Offset)
+}
+
+// Name returns the file name of the timecode `tc`.  It must have the rate of the sequence.
+func (s Sequence) Name(tc timecode.Timecode) (string, error) {
+	if tc.Rate() != s.Rate {
+		return "", timecode.ErrInconsistentFPS
+	}
+	n := tc.Frame() - s.Offset
+	if n < 0 {
+		return "", errors.Wrapf(timecode.ErrInvalidTimeCode, "%v before the first file", &tc)
+	}
+	return s.Pattern.Name(n), nil
+}
+
+// Names returns the file names of the frames of the range `r`.  The file numbers keep increasing when the
+// range crosses midnight.
+func (s Sequence) Names(r timecode.Range) ([]string, error) {
+	if r.Duration < 0 {
+		return nil, errors.Wrapf(timecode.ErrInvalidRange, "duration %d", r.Duration)
+	}
+	first, err := s.Name(r.Start)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, r.Duration)
+	if r.Duration > 0 {
+		names = append(names, first)
+	}
+	n := r.Start.Frame() - s.Offset
+	for i := 1; i < r.Duration; i++ {
+		names = append(names, s.Pattern.Name(n+i))
+	}
+	return names, nil
+}
+
+// Missing returns the ranges of the timecodes missing between the first and the last file names of
+// `names` that match the pattern.  The other names are ignored.
+func (s Sequence) Missing(names []string) ([]timecode.Range, error) {
+	frames := s.Pattern.Frames(names)
+	var missing []timecode.Range
+	for i := 1; i < len(frames); i++ {
+		if frames[i]-frames[i-1] <= 1 {
+			continue
+		}
+		start, err := timecode.NewFromRate(s.Rate, frames[i-1]+1+s.Offset)
+		if err != nil {
+			return nil, err
+		}
+		missing = append(missing, timecode.Range{Start: *start, Duration: frames[i] - frames[i-1] - 1})
+	}
+	return missing, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,167 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package imgseq
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wunderbarb/timecode"
+)
+
+var testCounter int
+
+func TestParsePattern(t *testing.T) {
+	require, assert := Describe(t)
+
+	tests := []struct {
+		p          string
+		exp        Pattern
+		expSuccess bool
+	}{
+		{"shot_010.%06d.exr", Pattern{Prefix: "shot_010.", Suffix: ".exr", Padding: 6}, true},
+		{"shot_010.%d.exr", Pattern{Prefix: "shot_010.", Suffix: ".exr"}, true},
+		{"shot_010.######.exr", Pattern{Prefix: "shot_010.", Suffix: ".exr", Padding: 6}, true},
+		{"plates/a_####.dpx", Pattern{Prefix: "plates/a_", Suffix: ".dpx", Padding: 4}, true},
+		{"shot_010.exr", Pattern{}, false},
+		{"shot_010.%6d.exr", Pattern{}, false},
+		{"shot_010.%06x.exr", Pattern{}, false},
+		{"shot_%02d.%06d.exr", Pattern{}, false},
+		{"shot_##.####.exr", Pattern{}, false},
+	}
+	for i, tt := range tests {
+		p, err := ParsePattern(tt.p)
+		require.Equal(tt.expSuccess, err == nil, "sample %d", i+1)
+		if err == nil {
+			assert.Equal(tt.exp, p, "sample %d", i+1)
+		} else {
+			assert.ErrorIs(err, ErrInvalidPattern, "sample %d", i+1)
+		}
+	}
+	p, _ := ParsePattern("shot_010.######.exr")
+	assert.Equal("shot_010.%06d.exr", p.String())
+	p, _ = ParsePattern("a.%d.exr")
+	assert.Equal("a.%d.exr", p.String())
+}
+
+func TestParseName(t *testing.T) {
+	require, assert := Describe(t)
+
+	p, n, err := ParseName("shot_010.086400.exr")
+	require.NoError(err)
+	assert.Equal(Pattern{Prefix: "shot_010.", Suffix: ".exr", Padding: 6}, p)
+	assert.Equal(86400, n)
+	p, n, err = ParseName("/mnt/plates/A001_C002_0001234_v2.dpx")
+	require.NoError(err)
+	assert.Equal("/mnt/plates/A001_C002_0001234_v", p.Prefix)
+	assert.Equal(2, n)
+	_, _, err = ParseName("shot.exr")
+	assert.ErrorIs(err, ErrNoMatch)
+	_, _, err = ParseName("shot_010/.exr")
+	assert.ErrorIs(err, ErrNoMatch)
+}
+
+func TestPattern_Frame(t *testing.T) {
+	_, assert := Describe(t)
+
+	p, _ := ParsePattern("shot_010.%06d.exr")
+	tests := []struct {
+		name       string
+		expFrame   int
+		expSuccess bool
+	}{
+		{"shot_010.086400.exr", 86400, true},
+		{"shot_010.000000.exr", 0, true},
+		{"shot_010.1234567.exr", 1234567, true},
+		{"shot_010.86400.exr", 0, false},
+		{"shot_010.0086400.exr", 0, false},
+		{"shot_010.08640a.exr", 0, false},
+		{"shot_020.086400.exr", 0, false},
+		{"shot_010.086400.dpx", 0, false},
+		{"shot_010..exr", 0, false},
+	}
+	for i, tt := range tests {
+		n, err := p.Frame(tt.name)
+		assert.Equal(tt.expSuccess, err == nil, "sample %d", i+1)
+		assert.Equal(tt.expFrame, n, "sample %d", i+1)
+	}
+	assert.Equal("shot_010.000042.exr", p.Name(42))
+	assert.Equal([]int{1, 3}, p.Frames([]string{"shot_010.000003.exr", "notes.txt", "shot_010.000001.exr"}))
+}
+
+func TestSequence(t *testing.T) {
+	require, assert := Describe(t)
+
+	p, _ := ParsePattern("shot_010.%06d.exr")
+	s := Sequence{Pattern: p, Rate: timecode.Rate24}
+	tc, err := s.Timecode("shot_010.086400.exr")
+	require.NoError(err)
+	assert.Equal("01:00:00:00", tc.String())
+	name, err := s.Name(*tc)
+	require.NoError(err)
+	assert.Equal("shot_010.086400.exr", name)
+
+	s.Offset = 86400 - 1001
+	tc, _ = s.Timecode("shot_010.001001.exr")
+	assert.Equal("01:00:00:00", tc.String())
+
+	names, err := s.Names(timecode.Range{Start: *tc, Duration: 3})
+	require.NoError(err)
+	assert.Equal([]string{"shot_010.001001.exr", "shot_010.001002.exr", "shot_010.001003.exr"}, names)
+	// The range crosses midnight.
+	s.Offset = 0
+	tc, _ = timecode.NewFromRate(timecode.Rate24, 24*3600*24-1)
+	names, err = s.Names(timecode.Range{Start: *tc, Duration: 2})
+	require.NoError(err)
+	assert.Equal([]string{"shot_010.2073599.exr", "shot_010.2073600.exr"}, names)
+	_, err = s.Names(timecode.Range{Start: *tc, Duration: -1})
+	assert.ErrorIs(err, timecode.ErrInvalidRange)
+	s.Offset = 86400 - 1001
+
+	other, _ := timecode.NewFromRate(timecode.Rate25, 86400)
+	_, err = s.Name(*other)
+	assert.ErrorIs(err, timecode.ErrInconsistentFPS)
+	early, _ := timecode.NewFromRate(timecode.Rate24, 0)
+	_, err = s.Name(*early)
+	assert.ErrorIs(err, timecode.ErrInvalidTimeCode)
+}
+
+func TestSequence_Missing(t *testing.T) {
+	require, assert := Describe(t)
+
+	p, _ := ParsePattern("shot_010.####.exr")
+	s := Sequence{Pattern: p, Rate: timecode.Rate24, Offset: 86400}
+	listing := []string{"shot_010.0005.exr", "shot_010.0001.exr", "shot_010.0002.exr", "thumbs.db",
+		"shot_010.0009.exr", "shot_010.0006.exr"}
+	missing, err := s.Missing(listing)
+	require.NoError(err)
+	require.Len(missing, 2)
+	assert.Equal("01:00:00:03-01:00:00:05", missing[0].String())
+	assert.Equal("01:00:00:07-01:00:00:09", missing[1].String())
+
+	missing, err = s.Missing(listing[1:4])
+	require.NoError(err)
+	assert.Empty(missing)
+}
+
+// Describe displays the rank of the test, the name of the function
+// and its optional description provided by 'msg'.  It initializes an assert
+// and a require function and returns them.
+func Describe(t *testing.T, msg ...string) (*require.Assertions,
+	*assert.Assertions) {
+
+	dispMsg := ""
+	if len(msg) != 0 {
+		dispMsg = msg[0]
+	}
+	name := strings.TrimPrefix(strings.TrimPrefix(t.Name(), "Test"), "_")
+	fmt.Printf("Test %d: %s %s\n", testCounter, name, dispMsg)
+	testCounter++
+	return require.New(t), assert.New(t)
+}
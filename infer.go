@@ -0,0 +1,220 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrMismatchedTimestamps is returned when the timestamps do not match the timecodes.
+var ErrMismatchedTimestamps = errors.New("timestamps do not match timecodes")
+
+// _candidateRates are the rates considered by InferRate.
+var _candidateRates = []Rate{Rate23976, Rate24, Rate25, Rate2997, Rate2997DF, Rate30}
+
+var _reLenient = regexp.MustCompile(`^(\d{1,2})[:.](\d{2})[:.](\d{2})([:;.])(\d{2})$`)
+
+// RateCandidate is a frame rate inferred by InferRate with its confidence.
+type RateCandidate struct {
+	Rate
+	// Confidence is between 0 and 1.  The confidences of all the candidates sum to 1.
+	Confidence float64
+}
+
+// label is a parsed timecode label.
+type label struct {
+	h, m, s, f int
+	semicolon  bool
+}
+
+// InferRate infers the most likely frame rates and drop frame modes of the timecode strings `ts`.
+// The candidates are 23.976, 24, 25, 29.97, 29.97 DF and 30 FPS.  They are returned by decreasing
+// confidence.  The impossible candidates are not returned.
+//
+// The inference uses the highest frame field, the `;` separators and the labels skipped or used at
+// the minute boundaries in drop frame.  If `timestamps` is not nil, it holds the wall-clock time of each
+// timecode, e.g., the elapsed time since the start of the recording.  It allows to distinguish the
+// NTSC rates from the integer rates.
+// The strings that are not timecodes are ignored.
+func InferRate(ts []string, timestamps []time.Duration) ([]RateCandidate, error) {
+	if timestamps != nil && len(timestamps) != len(ts) {
+		return nil, ErrMismatchedTimestamps
+	}
+	labels := make([]*label, len(ts))
+	n := 0
+	for i, s := range ts {
+		labels[i] = parseLabel(s)
+		if labels[i] != nil {
+			n++
+		}
+	}
+	if n == 0 {
+		return nil, ErrInvalidTimeCode
+	}
+	var res []RateCandidate
+	total := 0.0
+	for _, r := range _candidateRates {
+		sc := scoreFrames(r, labels, n) * scoreDropFrame(r, labels)
+		if timestamps != nil {
+			sc *= scoreTimestamps(r, labels, timestamps)
+		}
+		if sc > 0 {
+			res = append(res, RateCandidate{Rate: r, Confidence: sc})
+			total += sc
+		}
+	}
+	if total == 0 {
+		return nil, ErrInvalidFPS
+	}
+	for i := range res {
+		res[i].Confidence /= total
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Confidence > res[j].Confidence })
+	return res, nil
+}
+
+// scoreFrames returns the likelihood that `n` uniformly distributed frame fields are all lower than or
+// equal to the highest observed frame field.
+func scoreFrames(r Rate, labels []*label, n int) float64 {
+	maxF := 0
+	for _, l := range labels {
+		if l != nil && l.f > maxF {
+			maxF = l.f
+		}
+	}
+	nominal := cast2Round(r.FPS)
+	if maxF >= nominal {
+		return 0
+	}
+	return math.Pow(float64(maxF+1)/float64(nominal), float64(n))
+}
+
+// scoreDropFrame weighs the evidence of drop frame, i.e., the `;` separators, the labels skipped by
+// drop frame and the transitions from the last frame of a minute to the frame 2 of the next minute.
+func scoreDropFrame(r Rate, labels []*label) float64 {
+	const (
+		cEvidence = 10.0
+		cLastSec  = 59
+		cTenMin   = 10
+		cDropped  = 2
+	)
+	semicolon := false
+	skipped := false
+	dropped := false
+	var prev *label
+	for _, l := range labels {
+		if l == nil {
+			prev = nil
+			continue
+		}
+		semicolon = semicolon || l.semicolon
+		if l.s == 0 && l.m%cTenMin != 0 {
+			dropped = dropped || l.f < cDropped
+			skipped = skipped || (l.f == cDropped && prev != nil && prev.s == cLastSec &&
+				prev.f == cast2Round(FPS2997)-1)
+		}
+		prev = l
+	}
+	if r.DropFrame && dropped {
+		return 0
+	}
+	sc := 1.0
+	for _, evidence := range []bool{semicolon, skipped} {
+		switch {
+		case evidence && r.DropFrame:
+			sc *= cEvidence
+		case evidence:
+			sc /= cEvidence
+		}
+	}
+	return sc
+}
+
+// scoreTimestamps compares the frame rate measured between the first and last timecodes with the
+// frame rate of the candidate.
+func scoreTimestamps(r Rate, labels []*label, timestamps []time.Duration) float64 {
+	first, last := -1, -1
+	for i, l := range labels {
+		if l == nil {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		last = i
+	}
+	elapsed := (timestamps[last] - timestamps[first]).Seconds()
+	if elapsed <= 0 {
+		return 1
+	}
+	f1, err1 := labels[first].frame(r)
+	f2, err2 := labels[last].frame(r)
+	if err1 != nil || err2 != nil {
+		return 0
+	}
+	measured := float64(f2-f1) / elapsed
+	// The tolerance accounts for the quantization of the timecodes and of the timestamps.
+	sigma := 2/(r.FPS*elapsed) + 1e-4 //nolint:gomnd
+	rel := (measured - r.FPS) / r.FPS / sigma
+	return math.Exp(-rel * rel)
+}
+
+// frame returns the frame count of the label at the rate `r` regardless of its separator.
+func (l *label) frame(r Rate) (int, error) {
+	sep := ':'
+	if r.DropFrame {
+		sep = ';'
+	}
+	tc, err := NewFromRate(r, 0)
+	if err != nil {
+		return 0, err
+	}
+	if err := tc.Parse(fmt.Sprintf("%02d:%02d:%02d%c%02d", l.h, l.m, l.s, sep, l.f)); err != nil {
+		return 0, err
+	}
+	return tc.Frame(), nil
+}
+
+// parseLabel returns the fields of the timecode string `s`, or nil if it is not a timecode.
+func parseLabel(s string) *label {
+	const (
+		cHour = iota + 1
+		cMin
+		cSec
+		cSep
+		cFrame
+		cMaxMinSec = 59
+	)
+	m := _reLenient.FindStringSubmatch(s)
+	if m == nil {
+		return nil
+	}
+	l := &label{
+		h:         atoi(m[cHour]),
+		m:         atoi(m[cMin]),
+		s:         atoi(m[cSec]),
+		f:         atoi(m[cFrame]),
+		semicolon: m[cSep] == ";",
+	}
+	if l.m > cMaxMinSec || l.s > cMaxMinSec {
+		return nil
+	}
+	return l
+}
+
+// atoi converts a string of decimal digits.
+func atoi(s string) int {
+	n := 0
+	for _, r := range s {
+		n = 10*n + isDigit(r)
+	}
+	return n
+}
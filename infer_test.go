@@ -0,0 +1,96 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"testing"
+	"time"
+)
+
+// sequence returns `n` consecutive timecode strings starting at `frame` and their wall-clock timestamps.
+func sequence(r Rate, frame int, n int) ([]string, []time.Duration) {
+	ts := make([]string, n)
+	stamps := make([]time.Duration, n)
+	for i := range ts {
+		tc, _ := NewFromRate(r, frame+i)
+		ts[i] = tc.String()
+		stamps[i] = time.Duration(float64(i) / r.FPS * float64(time.Second))
+	}
+	return ts, stamps
+}
+
+func TestInferRate(t *testing.T) {
+	require, assert := Describe(t)
+
+	tests := []struct {
+		r          Rate
+		frame      int
+		n          int
+		timestamps bool
+		expRes     Rate
+	}{
+		{Rate25, 0, 100, false, Rate25},
+		{Rate2997DF, 1700, 200, false, Rate2997DF},
+		{Rate23976, 0, 2000, true, Rate23976},
+		{Rate24, 0, 2000, true, Rate24},
+		{Rate2997, 0, 5000, true, Rate2997},
+		{Rate30, 0, 5000, true, Rate30},
+	}
+	for i, tt := range tests {
+		ts, stamps := sequence(tt.r, tt.frame, tt.n)
+		if !tt.timestamps {
+			stamps = nil
+		}
+		res, err := InferRate(ts, stamps)
+		require.NoError(err, "sample %d", i+1)
+		assert.Equal(tt.expRes, res[0].Rate, "sample %d", i+1)
+		assert.Greater(res[0].Confidence, 0.5, "sample %d", i+1)
+		sum := 0.0
+		for _, c := range res {
+			sum += c.Confidence
+		}
+		assert.InDelta(1.0, sum, 1e-9, "sample %d", i+1)
+	}
+
+	// Without timestamps, 23.976 and 24 are undistinguishable.
+	ts, _ := sequence(Rate24, 0, 100)
+	res, err := InferRate(ts, nil)
+	require.NoError(err)
+	require.Len(res, 6)
+	assert.InDelta(res[0].Confidence, res[1].Confidence, 1e-9)
+
+	// Drop frame without `;` is detected from the skipped labels.
+	ts = []string{"00:00:59:28", "00:00:59:29", "00:01:00:02", "00:01:00:03"}
+	res, err = InferRate(ts, nil)
+	require.NoError(err)
+	assert.Equal(Rate2997DF, res[0].Rate)
+	// Dropped labels exclude drop frame.
+	ts = []string{"00:00:59;29", "00:01:00;00", "00:01:00;01"}
+	res, err = InferRate(ts, nil)
+	require.NoError(err)
+	for _, c := range res {
+		assert.False(c.DropFrame)
+	}
+
+	_, err = InferRate([]string{"bad"}, nil)
+	assert.ErrorIs(err, ErrInvalidTimeCode)
+	_, err = InferRate([]string{"00:00:00:00"}, []time.Duration{0, 1})
+	assert.ErrorIs(err, ErrMismatchedTimestamps)
+}
+
+func TestRate_String(t *testing.T) {
+	require, assert := Describe(t)
+
+	assert.Equal("29.97DF", Rate2997DF.String())
+	assert.Equal("23.976", Rate23976.String())
+	assert.Equal("25", Rate25.String())
+	assert.False(Rate{FPS: 25, DropFrame: true}.Valid())
+	_, err := NewFromRate(Rate{FPS: 25, DropFrame: true}, 0)
+	assert.ErrorIs(err, ErrInvalidFPS)
+	tc, err := NewFromRate(Rate2997DF, 1800)
+	require.NoError(err)
+	assert.Equal("00:01:00;02", tc.String())
+	assert.Equal(Rate2997DF, tc.Rate())
+}
@@ -0,0 +1,27 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+//go:build go1.23
+
+package timecode
+
+import (
+	"iter"
+)
+
+// All returns the sequence of the timecodes of the range configured by `opts`.
+//
+//	for tc := range r.All(timecode.IterOptions{Step: 2}) {
+//		...
+//	}
+func (r Range) All(opts IterOptions) iter.Seq[Timecode] {
+	return func(yield func(Timecode) bool) {
+		it := r.Iterator(opts)
+		for it.Next() {
+			if !yield(it.Timecode()) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,25 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+//go:build go1.23
+
+package timecode
+
+import (
+	"testing"
+)
+
+func TestRange_All(t *testing.T) {
+	_, assert := Describe(t)
+
+	start, _ := NewWithDropFrameFromString("00:09:59;28")
+	var got []string
+	for tc := range (Range{Start: *start, Duration: 10}).All(IterOptions{Step: 2}) {
+		got = append(got, tc.String())
+		if len(got) == 3 {
+			break
+		}
+	}
+	assert.Equal([]string{"00:09:59;28", "00:10:00;00", "00:10:00;02"}, got)
+}
@@ -0,0 +1,70 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+// IterOptions configures the iteration over a range.
+type IterOptions struct {
+	// Step is the number of frames between two timecodes.  Zero means 1.
+	Step int
+	// Reverse iterates from the end of the range to its start.
+	Reverse bool
+	// Inclusive includes the end of the range, i.e., the first frame after the range.
+	Inclusive bool
+}
+
+// Iterator walks the frames of a range.  As it walks frames, it never returns a label dropped by drop
+// frame.  A range crossing midnight wraps to 00:00:00:00.
+//
+//	it := r.Iterator(timecode.IterOptions{})
+//	for it.Next() {
+//		tc := it.Timecode()
+//		...
+//	}
+type Iterator struct {
+	tc      Timecode
+	start   int
+	last    int
+	step    int
+	reverse bool
+	day     int
+	offset  int
+	started bool
+}
+
+// Iterator returns an iterator over the frames of the range configured by `opts`.
+func (r Range) Iterator(opts IterOptions) *Iterator {
+	step := opts.Step
+	if step <= 0 {
+		step = 1
+	}
+	last := r.Duration - 1
+	if opts.Inclusive {
+		last++
+	}
+	return &Iterator{tc: r.Start, start: r.Start.currentFrame, last: last, step: step, reverse: opts.Reverse,
+		day: r.Start.framesPerDay()}
+}
+
+// Next advances the iterator to the next timecode.  It returns false when the range is exhausted.
+func (it *Iterator) Next() bool {
+	if it.started {
+		it.offset += it.step
+	}
+	it.started = true
+	if it.offset > it.last {
+		return false
+	}
+	f := it.start + it.offset
+	if it.reverse {
+		f = it.start + it.last - it.offset
+	}
+	it.tc.currentFrame = f % it.day
+	return true
+}
+
+// Timecode returns the current timecode.  It is valid after a call to Next that returned true.
+func (it *Iterator) Timecode() Timecode {
+	return it.tc
+}
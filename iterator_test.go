@@ -0,0 +1,57 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"testing"
+)
+
+func TestRange_Iterator(t *testing.T) {
+	_, assert := Describe(t)
+
+	start, _ := NewWithDropFrameFromString("00:00:59;28")
+	r := Range{Start: *start, Duration: 4}
+	tests := []struct {
+		opts IterOptions
+		exp  []string
+	}{
+		{IterOptions{}, []string{"00:00:59;28", "00:00:59;29", "00:01:00;02", "00:01:00;03"}},
+		{IterOptions{Inclusive: true}, []string{"00:00:59;28", "00:00:59;29", "00:01:00;02", "00:01:00;03",
+			"00:01:00;04"}},
+		{IterOptions{Step: 2}, []string{"00:00:59;28", "00:01:00;02"}},
+		{IterOptions{Reverse: true}, []string{"00:01:00;03", "00:01:00;02", "00:00:59;29", "00:00:59;28"}},
+		{IterOptions{Reverse: true, Inclusive: true, Step: 3}, []string{"00:01:00;04", "00:00:59;29"}},
+	}
+	for i, tt := range tests {
+		var got []string
+		it := r.Iterator(tt.opts)
+		for it.Next() {
+			tc := it.Timecode()
+			got = append(got, tc.String())
+		}
+		assert.Equal(tt.exp, got, "sample %d", i+1)
+	}
+
+	var got []string
+	it := Range{Start: *start, Duration: 0}.Iterator(IterOptions{})
+	for it.Next() {
+		got = append(got, "unexpected")
+	}
+	assert.Empty(got)
+}
+
+func TestRange_Iterator_Midnight(t *testing.T) {
+	require, assert := Describe(t)
+
+	start, _ := NewFromString(cFPS25, "23:59:59:23")
+	it := Range{Start: *start, Duration: 4}.Iterator(IterOptions{})
+	var got []string
+	for it.Next() {
+		tc := it.Timecode()
+		got = append(got, tc.String())
+	}
+	require.Len(got, 4)
+	assert.Equal([]string{"23:59:59:23", "23:59:59:24", "00:00:00:00", "00:00:00:01"}, got)
+}
@@ -0,0 +1,315 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidLayout is returned when a layout is not valid.
+var ErrInvalidLayout = errors.New("invalid layout")
+
+const cFramesPerFoot = 16
+
+// tokenKind is the kind of a layout token.
+type tokenKind int
+
+const (
+	tokLiteral tokenKind = iota
+	tokHour
+	tokMinute
+	tokSecond
+	tokFrame
+	tokMillisecond
+	tokTotalFrames
+	tokTotalFields
+	tokFootage
+	tokSeparator
+)
+
+// token is an element of a layout.  `width` is the number of digits.  A width of 0 means unpadded.
+type token struct {
+	kind    tokenKind
+	width   int
+	literal string
+}
+
+// FormatLayout returns the timecode formatted according to the layout.  The layout uses the following
+// tokens:
+//
+//	hh or HH   hours with two digits       h or H   hours
+//	mm or MM   minutes with two digits     m or M   minutes
+//	ss or SS   seconds with two digits     s or S   seconds
+//	ff or FF   frames with two digits      f or F   frames
+//	zzz        frames expressed in milliseconds at the nominal rate
+//	n          total frame count, i.e., Frame(); nnnnnn pads it to six digits
+//	i          total field count, i.e., twice the frame count; iiiiii pads it to six digits
+//	k          35 mm 4-perf footage feet+frames; kkkk pads the feet to four digits
+//	#          separator `;` in drop frame, `:` otherwise
+//	'text'     literal text; '' is a single quote
+//
+// The other characters are copied.  For instance, String is FormatLayout("hh:mm:ss#ff"),
+// "HHMMSSFF" is suited to file names and "h'h' m'm' s's'" gives `1h 2m 3s`.
+func (t *Timecode) FormatLayout(layout string) (string, error) {
+	tokens, err := tokenize(layout)
+	if err != nil {
+		return "", err
+	}
+	h1, m1, s1, fr := t.fields()
+	var sb strings.Builder
+	for _, tok := range tokens {
+		switch tok.kind {
+		case tokLiteral:
+			sb.WriteString(tok.literal)
+		case tokHour:
+			sb.WriteString(pad(h1, tok.width))
+		case tokMinute:
+			sb.WriteString(pad(m1, tok.width))
+		case tokSecond:
+			sb.WriteString(pad(s1, tok.width))
+		case tokFrame:
+			sb.WriteString(pad(fr, tok.width))
+		case tokMillisecond:
+			sb.WriteString(pad(fr*cPrecision/cast2Round(t.fps), tok.width))
+		case tokTotalFrames:
+			sb.WriteString(pad(t.currentFrame, tok.width))
+		case tokTotalFields:
+			sb.WriteString(pad(2*t.currentFrame, tok.width))
+		case tokFootage:
+			sb.WriteString(pad(t.currentFrame/cFramesPerFoot, tok.width) + "+" +
+				pad(t.currentFrame%cFramesPerFoot, 2)) //nolint:gomnd
+		case tokSeparator:
+			sb.WriteString(t.separator())
+		}
+	}
+	return sb.String(), nil
+}
+
+// ParseLayout parses the string `s` formatted according to the layout and returns the timecode with
+// the rate `r`.  The layout uses the tokens of FormatLayout.  The separator token accepts `:` and `;`.
+// The timecode is defined by, by order of precedence, the total frame count, the total field count, the
+// footage or the hours, minutes, seconds and frames.  The missing fields are 0.
+func ParseLayout(layout string, r Rate, s string) (*Timecode, error) {
+	tokens, err := tokenize(layout)
+	if err != nil {
+		return nil, err
+	}
+	tc, err := NewFromRate(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[tokenKind]int)
+	feet, pos := -1, 0
+	for i, tok := range tokens {
+		switch tok.kind {
+		case tokLiteral:
+			if !strings.HasPrefix(s[pos:], tok.literal) {
+				return nil, ErrInvalidTimeCode
+			}
+			pos += len(tok.literal)
+			continue
+		case tokSeparator:
+			if pos >= len(s) || (s[pos] != ':' && s[pos] != ';') {
+				return nil, ErrInvalidTimeCode
+			}
+			pos++
+			continue
+		default:
+		}
+		v, n := scanDigits(s[pos:], tok.width, i+1 < len(tokens) && tokens[i+1].kind != tokLiteral)
+		if n == 0 {
+			return nil, ErrInvalidTimeCode
+		}
+		pos += n
+		if tok.kind == tokFootage {
+			if !strings.HasPrefix(s[pos:], "+") {
+				return nil, ErrInvalidTimeCode
+			}
+			feet = v
+			v, n = scanDigits(s[pos+1:], 2, false) //nolint:gomnd
+			if n == 0 {
+				return nil, ErrInvalidTimeCode
+			}
+			pos += n + 1
+		}
+		values[tok.kind] = v
+	}
+	if pos != len(s) {
+		return nil, ErrInvalidTimeCode
+	}
+	return tc, tc.setLayoutValues(values, feet)
+}
+
+// setLayoutValues sets the timecode from the parsed values.
+func (t *Timecode) setLayoutValues(values map[tokenKind]int, feet int) error {
+	const cMaxMinSec = 59
+	if v, ok := values[tokTotalFrames]; ok {
+		t.currentFrame = v
+		return nil
+	}
+	if v, ok := values[tokTotalFields]; ok {
+		t.currentFrame = v / 2 //nolint:gomnd
+		return nil
+	}
+	if feet >= 0 {
+		if values[tokFootage] >= cFramesPerFoot {
+			return ErrInvalidTimeCode
+		}
+		t.currentFrame = feet*cFramesPerFoot + values[tokFootage]
+		return nil
+	}
+	f := values[tokFrame]
+	if v, ok := values[tokMillisecond]; ok && f == 0 {
+		f = cast2Round(float64(v*cast2Round(t.fps)) / cPrecision)
+	}
+	if values[tokMinute] > cMaxMinSec || values[tokSecond] > cMaxMinSec {
+		return ErrInvalidTimeCode
+	}
+	return t.setFields(values[tokHour], values[tokMinute], values[tokSecond], f)
+}
+
+// separator returns the separator between the seconds and the frames.
+func (t *Timecode) separator() string {
+	if t.dropFrame {
+		return ";"
+	}
+	return ":"
+}
+
+// tokenize splits the layout into tokens.
+func tokenize(layout string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(layout); {
+		c := layout[i]
+		if c == '\'' {
+			lit, n, err := quoted(layout[i:])
+			if err != nil {
+				return nil, err
+			}
+			tokens = appendLiteral(tokens, lit)
+			i += n
+			continue
+		}
+		kind, maxWidth := tokenOf(c)
+		if kind == tokLiteral {
+			tokens = appendLiteral(tokens, string(c))
+			i++
+			continue
+		}
+		n := 1
+		for i+n < len(layout) && layout[i+n] == c {
+			n++
+		}
+		if maxWidth > 0 && n > maxWidth {
+			return nil, errors.Wrapf(ErrInvalidLayout, "%q", layout[i:i+n])
+		}
+		tok := token{kind: kind, width: n}
+		if n == 1 && kind != tokSeparator {
+			tok.width = 0
+		}
+		if kind == tokSeparator {
+			for j := 0; j < n; j++ {
+				tokens = append(tokens, token{kind: tokSeparator})
+			}
+		} else {
+			tokens = append(tokens, tok)
+		}
+		i += n
+	}
+	return tokens, nil
+}
+
+// tokenOf returns the kind of the token starting with `c` and its maximal width, 0 if unlimited.
+func tokenOf(c byte) (tokenKind, int) {
+	const (
+		cTwoDigits   = 2
+		cThreeDigits = 3
+	)
+	switch c {
+	case 'h', 'H':
+		return tokHour, cTwoDigits
+	case 'm', 'M':
+		return tokMinute, cTwoDigits
+	case 's', 'S':
+		return tokSecond, cTwoDigits
+	case 'f', 'F':
+		return tokFrame, cTwoDigits
+	case 'z':
+		return tokMillisecond, cThreeDigits
+	case 'n':
+		return tokTotalFrames, 0
+	case 'i':
+		return tokTotalFields, 0
+	case 'k':
+		return tokFootage, 0
+	case '#':
+		return tokSeparator, 0
+	default:
+		return tokLiteral, 0
+	}
+}
+
+// quoted returns the literal of the quoted text at the start of `s` and the number of bytes consumed.
+// Within the quoted text, two consecutive quotes are a single quote.
+func quoted(s string) (string, int, error) {
+	if strings.HasPrefix(s, "''") {
+		return "'", 2, nil //nolint:gomnd
+	}
+	var sb strings.Builder
+	for j := 1; j < len(s); j++ {
+		if s[j] != '\'' {
+			sb.WriteByte(s[j])
+			continue
+		}
+		if j+1 < len(s) && s[j+1] == '\'' {
+			sb.WriteByte('\'')
+			j++
+			continue
+		}
+		return sb.String(), j + 1, nil
+	}
+	return "", 0, errors.Wrapf(ErrInvalidLayout, "unterminated quote %q", s)
+}
+
+func appendLiteral(tokens []token, lit string) []token {
+	if len(tokens) > 0 && tokens[len(tokens)-1].kind == tokLiteral {
+		tokens[len(tokens)-1].literal += lit
+		return tokens
+	}
+	return append(tokens, token{kind: tokLiteral, literal: lit})
+}
+
+// pad returns the decimal representation of `v` padded with zeros to `width` digits.
+func pad(v int, width int) string {
+	s := strconv.Itoa(v)
+	if len(s) < width {
+		s = strings.Repeat("0", width-len(s)) + s
+	}
+	return s
+}
+
+// scanDigits returns the value of the digits at the start of `s` and their number.  If `width` is not 0,
+// it reads exactly `width` digits.  Otherwise, it reads all the digits unless `bounded` is true, in
+// which case it reads at most two digits so that an unpadded field can be followed by another field.
+func scanDigits(s string, width int, bounded bool) (int, int) {
+	limit := len(s)
+	if width > 0 {
+		limit = min(width, len(s))
+	} else if bounded {
+		limit = min(2, len(s)) //nolint:gomnd
+	}
+	n, v := 0, 0
+	for n < limit && s[n] >= '0' && s[n] <= '9' {
+		v = 10*v + int(s[n]-'0')
+		n++
+	}
+	if width > 0 && n != width {
+		return 0, 0
+	}
+	return v, n
+}
@@ -0,0 +1,94 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"testing"
+)
+
+func TestTimecode_FormatLayout(t *testing.T) {
+	require, assert := Describe(t)
+
+	tests := []struct {
+		tc      string
+		r       Rate
+		layout  string
+		expRes  string
+		partial bool
+	}{
+		{"01:02:03:04", Rate25, "hh:mm:ss:ff", "01:02:03:04", false},
+		{"01:02:03:04", Rate25, "hh.mm.ss.ff", "01.02.03.04", false},
+		{"01:02:03:04", Rate25, "HHMMSSFF", "01020304", false},
+		{"01:02:03:04", Rate25, "h'h' m'm' s's'", "1h 2m 3s", true},
+		{"01:02:03:04", Rate25, "hh:mm:ss#ff", "01:02:03:04", false},
+		{"01:02:03;04", Rate2997DF, "hh:mm:ss#ff", "01:02:03;04", false},
+		{"00:00:01:12", Rate24, "hh:mm:ss.zzz", "00:00:01.500", false},
+		{"01:00:00:00", Rate24, "nnnnnnn", "0086400", false},
+		{"01:00:00:00", Rate24, "n", "86400", false},
+		{"00:00:01:00", Rate24, "i", "48", false},
+		{"00:00:56:08", Rate24, "kkkk", "0084+08", false},
+		{"00:00:56:08", Rate24, "'It''s' f", "It's 8", true},
+		{"00:00:56:08", Rate24, "f''", "8'", true},
+	}
+	for i, tt := range tests {
+		tc, err := NewFromString(tt.r.FPS, tt.tc)
+		if tt.r.DropFrame {
+			tc, err = NewWithDropFrameFromString(tt.tc)
+		}
+		require.NoError(err, "sample %d", i+1)
+		s, err := tc.FormatLayout(tt.layout)
+		require.NoError(err, "sample %d", i+1)
+		assert.Equal(tt.expRes, s, "sample %d", i+1)
+
+		if tt.partial {
+			// The layout does not hold all the fields.
+			continue
+		}
+		tc1, err := ParseLayout(tt.layout, tt.r, s)
+		require.NoError(err, "sample %d", i+1)
+		assert.Equal(tc.Frame(), tc1.Frame(), "sample %d", i+1)
+	}
+
+	tc, _ := NewFromFrame(cFPS25, 0)
+	for _, l := range []string{"hhh", "'hh", "mmm"} {
+		_, err := tc.FormatLayout(l)
+		assert.ErrorIs(err, ErrInvalidLayout, l)
+	}
+}
+
+func TestParseLayout(t *testing.T) {
+	require, assert := Describe(t)
+
+	tests := []struct {
+		layout     string
+		s          string
+		r          Rate
+		expRes     int
+		expSuccess bool
+	}{
+		{"h:mm:ss:ff", "1:00:00:01", Rate25, 90001, true},
+		{"h:m:s:f", "1:2:3:4", Rate25, 93079, true},
+		{"hmsf", "1234", Rate25, 97504, false},
+		{"HHMMSSFF", "01000001", Rate25, 90001, true},
+		{"hh'h'mm", "01h02", Rate25, 93000, true},
+		{"hh:mm:ss#ff", "00:01:00;02", Rate2997DF, 1800, true},
+		{"hh:mm:ss#ff", "00:01:00;00", Rate2997DF, 0, false},
+		{"hh:mm:ss#ff", "00:01:00-00", Rate25, 0, false},
+		{"hh:mm:ss:ff", "00:01:00:25", Rate25, 0, false},
+		{"hh:mm:ss:ff", "00:61:00:00", Rate25, 0, false},
+		{"hh:mm:ss:ff", "00:01:00:00x", Rate25, 0, false},
+		{"hh:mm:ss:ff", "00:01:00:0", Rate25, 0, false},
+		{"kkkk", "0001+16", Rate24, 0, false},
+		{"nnnn", "0001", Rate{}, 0, false},
+	}
+	for i, tt := range tests {
+		tc, err := ParseLayout(tt.layout, tt.r, tt.s)
+		require.Equal(tt.expSuccess, err == nil, "sample %d", i+1)
+		if err == nil {
+			assert.Equal(tt.expRes, tc.Frame(), "sample %d", i+1)
+			assert.Equal(tt.r, tc.Rate(), "sample %d", i+1)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+// Package mpeg2 encodes and decodes the 25-bit time_code of the group of pictures (GOP) headers of
+// MPEG-2 video (ISO/IEC 13818-2) and scans video elementary streams for the timecode of each GOP.
+//
+// The frame rate comes from the frame_rate_code of the sequence header and the drop frame mode from
+// the drop_frame_flag of the time_code.  The timecode package counts drop frames only at 29.97, so a
+// time_code with the drop_frame_flag at 59.94 is not decoded and returns ErrUnsupportedDropFrame.
+package mpeg2
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/wunderbarb/timecode"
+)
+
+var (
+	// ErrInvalidStream is returned when a sequence header or a GOP header is truncated.
+	ErrInvalidStream = errors.New("mpeg2: invalid stream")
+	// ErrNoSequenceHeader is returned when a GOP header precedes any sequence header.
+	ErrNoSequenceHeader = errors.New("mpeg2: no sequence header")
+	// ErrUnknownRate is the Err of the GOPs that follow a sequence header with a reserved frame_rate_code.
+	ErrUnknownRate = errors.New("mpeg2: unknown frame_rate_code")
+	// ErrUnsupportedDropFrame is returned when the drop_frame_flag is set at a rate other than 29.97.
+	ErrUnsupportedDropFrame = errors.New("mpeg2: drop frame only at 29.97")
+)
+
+const (
+	// FlagDropFrame is the drop_frame_flag of a time_code.
+	FlagDropFrame = 1 << 24
+	// FlagMarker is the marker bit, always set, between the minutes and the seconds of a time_code.
+	FlagMarker = 1 << 12
+)
+
+const (
+	cCodePicture        = 0x00
+	cCodeSequenceHeader = 0xB3
+	cCodeGOP            = 0xB8
+	cStartCodeSize      = 4
+	cHeaderSize         = 4
+	cHoursShift         = 19
+	cMinutesShift       = 13
+	cSecondsShift       = 6
+	cFieldMask          = 0x3F
+	cHoursMask          = 0x1F
+	// cTimeCodeShift is the position of the time_code in the 32 bits following the GOP start code.
+	cTimeCodeShift  = 7
+	cFlagClosedGOP  = 1 << 6
+	cFlagBrokenLink = 1 << 5
+	cFrameRateMask  = 0x0F
+)
+
+// _frameRates are the frame rates of the frame_rate_code of a sequence header.  The reserved codes are 0.
+var _frameRates = []float64{0, timecode.FPS23976fps, 24, 25, timecode.FPS2997, 30, 50, 2 * timecode.FPS2997, 60}
+
+// GOP is a group of pictures of an elementary stream.
+type GOP struct {
+	// Offset is the position of the start code of the GOP header in the stream.
+	Offset int64
+	// TimeCode is the raw 25-bit time_code of the GOP header.
+	TimeCode uint32
+	// ClosedGOP and BrokenLink are the closed_gop and broken_link flags of the GOP header.
+	ClosedGOP  bool
+	BrokenLink bool
+	// Pictures is the number of pictures of the GOP.
+	Pictures int
+	// Timecode is the decoded time_code, i.e., the timecode of the first picture of the GOP in display
+	// order.  It is the zero value if Err is not nil.
+	Timecode timecode.Timecode
+	// Err is the error of the decoding of TimeCode or nil.
+	Err error
+}
+
+// DecodeTimeCode returns the timecode, with the frame rate of `r`, of the 25-bit time_code `v`.  The
+// drop_frame_flag of `v` sets the drop frame mode.  The marker bit is ignored.  It returns
+// ErrUnsupportedDropFrame if the drop_frame_flag is set at a rate other than 29.97, e.g., 59.94.
+func DecodeTimeCode(r timecode.Rate, v uint32) (*timecode.Timecode, error) {
+	r.DropFrame = v&FlagDropFrame != 0
+	if r.DropFrame && r.FPS != timecode.FPS2997 {
+		return nil, errors.Wrapf(ErrUnsupportedDropFrame, "at %v", r.FPS)
+	}
+	return timecode.NewFromFields(r, int(v>>cHoursShift&cHoursMask), int(v>>cMinutesShift&cFieldMask),
+		int(v>>cSecondsShift&cFieldMask), int(v&cFieldMask))
+}
+
+// EncodeTimeCode returns the 25-bit time_code, with the drop_frame_flag and the marker bit, of the label
+// of the timecode `tc` wrapped at 24 hours.
+func EncodeTimeCode(tc timecode.Timecode) uint32 {
+	h, m, s, f := tc.Fields()
+	v := uint32(FlagMarker | h<<cHoursShift | m<<cMinutesShift | s<<cSecondsShift | f)
+	if tc.Rate().DropFrame {
+		v |= FlagDropFrame
+	}
+	return v
+}
+
+// Header returns the GOP header, with its start code, of the GOP.  It encodes TimeCode, ClosedGOP and
+// BrokenLink.
+func (g *GOP) Header() []byte {
+	v := g.TimeCode << cTimeCodeShift
+	if g.ClosedGOP {
+		v |= cFlagClosedGOP
+	}
+	if g.BrokenLink {
+		v |= cFlagBrokenLink
+	}
+	return binary.BigEndian.AppendUint32([]byte{0, 0, 1, cCodeGOP}, v)
+}
+
+// Scan returns the GOPs of the MPEG-2 video elementary stream `r` with their timecodes and numbers of
+// pictures.  A time_code that does not decode, or a reserved frame_rate_code, sets the Err of the GOPs
+// concerned and does not stop the scan.
+func Scan(r io.Reader) ([]GOP, error) {
+	s := &scanner{r: bufio.NewReader(r)}
+	var (
+		gops    []GOP
+		rate    timecode.Rate
+		rateErr error
+	)
+	for {
+		code, offset, err := s.next()
+		if err == io.EOF {
+			return gops, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch code {
+		case cCodePicture:
+			if len(gops) > 0 {
+				gops[len(gops)-1].Pictures++
+			}
+		case cCodeSequenceHeader:
+			b, err := s.read(cHeaderSize)
+			if err != nil {
+				return nil, errors.Wrapf(err, "sequence header at %d", offset)
+			}
+			c := int(b[3] & cFrameRateMask)
+			rate, rateErr = timecode.Rate{}, nil
+			if c >= len(_frameRates) || _frameRates[c] == 0 {
+				rateErr = errors.Wrapf(ErrUnknownRate, "frame_rate_code %d at %d", c, offset)
+				continue
+			}
+			rate = timecode.Rate{FPS: _frameRates[c]}
+		case cCodeGOP:
+			g, err := s.gop(rate, rateErr, offset)
+			if err != nil {
+				return nil, err
+			}
+			gops = append(gops, *g)
+		}
+	}
+}
+
+// Timecodes returns, for each GOP of `gops`, the timecode of the GOP followed by as many successive
+// timecodes as the GOP has other pictures, i.e., the timecodes of its pictures in display order whatever
+// their coded order.  The GOPs with an Err are skipped.  timecode.Analyze checks the continuity of the
+// result.
+func Timecodes(gops []GOP) []timecode.Timecode {
+	var tcs []timecode.Timecode
+	for _, g := range gops {
+		if g.Err != nil {
+			continue
+		}
+		for i := 0; i < max(g.Pictures, 1); i++ {
+			tc := g.Timecode
+			tc.Offset(i)
+			tcs = append(tcs, tc)
+		}
+	}
+	return tcs
+}
+
+// scanner finds the start codes of an elementary stream.
+type scanner struct {
+	r      *bufio.Reader
+	offset int64
+	zeros  int
+}
+
+// next returns the value of the next start code and its position.  It returns io.EOF at the end of the
+// stream.
+func (s *scanner) next() (byte, int64, error) {
+	for {
+		c, err := s.r.ReadByte()
+		if err != nil {
+			return 0, 0, err
+		}
+		s.offset++
+		switch {
+		case c == 0:
+			s.zeros++
+		case c == 1 && s.zeros >= 2:
+			s.zeros = 0
+			code, err := s.r.ReadByte()
+			if err != nil {
+				return 0, 0, err
+			}
+			s.offset++
+			return code, s.offset - cStartCodeSize, nil
+		default:
+			s.zeros = 0
+		}
+	}
+}
+
+// read returns the next `n` bytes of the stream.
+func (s *scanner) read(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(s.r, b); err != nil {
+		return nil, ErrInvalidStream
+	}
+	s.offset += int64(n)
+	return b, nil
+}
+
+// gop reads the GOP header at the position `offset` of a sequence with the rate `rate`, or with the
+// reserved frame_rate_code error `rateErr`.  The error of the decoding of the time_code, or `rateErr`, is
+// the Err of the GOP.
+func (s *scanner) gop(rate timecode.Rate, rateErr error, offset int64) (*GOP, error) {
+	if rate.FPS == 0 && rateErr == nil {
+		return nil, errors.Wrapf(ErrNoSequenceHeader, "GOP header at %d", offset)
+	}
+	b, err := s.read(cHeaderSize)
+	if err != nil {
+		return nil, errors.Wrapf(err, "GOP header at %d", offset)
+	}
+	v := binary.BigEndian.Uint32(b)
+	g := &GOP{Offset: offset, TimeCode: v >> cTimeCodeShift, ClosedGOP: v&cFlagClosedGOP != 0,
+		BrokenLink: v&cFlagBrokenLink != 0}
+	if rateErr != nil {
+		g.Err = errors.Wrapf(rateErr, "GOP header at %d", offset)
+		return g, nil
+	}
+	tc, err := DecodeTimeCode(rate, g.TimeCode)
+	if err != nil {
+		g.Err = errors.Wrapf(err, "GOP header at %d", offset)
+		return g, nil
+	}
+	g.Timecode = *tc
+	return g, nil
+}
@@ -0,0 +1,163 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package mpeg2
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wunderbarb/timecode"
+)
+
+var testCounter int
+
+func TestDecodeTimeCode(t *testing.T) {
+	_, assert := Describe(t)
+
+	r5994 := timecode.Rate{FPS: 2 * timecode.FPS2997}
+	tests := []struct {
+		r          timecode.Rate
+		v          uint32
+		exp        string
+		expRate    timecode.Rate
+		expSuccess bool
+	}{
+		{timecode.Rate25, FlagMarker | 10<<19 | 20<<13 | 30<<6 | 24, "10:20:30:24", timecode.Rate25, true},
+		{timecode.Rate2997, FlagDropFrame | FlagMarker | 1<<13 | 2, "00:01:00;02", timecode.Rate2997DF, true},
+		{timecode.Rate2997, 1<<13 | 2, "00:01:00:02", timecode.Rate2997, true},
+		{timecode.Rate2997, FlagDropFrame | 1<<13, "", timecode.Rate{}, false},
+		{timecode.Rate25, FlagDropFrame, "", timecode.Rate{}, false},
+		{timecode.Rate25, 25, "", timecode.Rate{}, false},
+		{timecode.Rate25, 24 << 19, "", timecode.Rate{}, false},
+		{timecode.Rate25, 60 << 6, "", timecode.Rate{}, false},
+		{r5994, 1<<13 | 2, "00:01:00:02", r5994, true},
+		{r5994, FlagDropFrame | 1<<13 | 4, "", timecode.Rate{}, false},
+	}
+	for i, tt := range tests {
+		tc, err := DecodeTimeCode(tt.r, tt.v)
+		assert.Equal(tt.expSuccess, err == nil, "sample %d", i+1)
+		if err == nil {
+			assert.Equal(tt.exp, tc.String(), "sample %d", i+1)
+			assert.Equal(tt.expRate, tc.Rate(), "sample %d", i+1)
+		}
+	}
+}
+
+func TestEncodeTimeCode(t *testing.T) {
+	require, assert := Describe(t)
+
+	tests := []struct {
+		r        timecode.Rate
+		frame    int
+		exp      uint32
+		expFrame int
+	}{
+		{timecode.Rate25, 0, FlagMarker, 0},
+		{timecode.Rate2997DF, 1800, FlagDropFrame | FlagMarker | 1<<13 | 2, 1800},
+		{timecode.Rate24, 24*3600*24 + 24*61 + 3, FlagMarker | 1<<13 | 1<<6 | 3, 24*61 + 3},
+		{timecode.Rate{FPS: 60}, 60*3600*23 + 59, FlagMarker | 23<<19 | 59, 60*3600*23 + 59},
+	}
+	for i, tt := range tests {
+		tc, err := timecode.NewFromRate(tt.r, tt.frame)
+		require.NoError(err, "sample %d", i+1)
+		v := EncodeTimeCode(*tc)
+		assert.Equal(tt.exp, v, "sample %d", i+1)
+		tc1, err := DecodeTimeCode(tt.r, v)
+		require.NoError(err, "sample %d", i+1)
+		assert.Equal(tt.expFrame, tc1.Frame(), "sample %d", i+1)
+	}
+}
+
+func TestScan(t *testing.T) {
+	require, assert := Describe(t)
+
+	labels := []string{"00:59:59;26", "01:00:00;00", "01:00:00;05"}
+	var stream []byte
+	for _, l := range labels {
+		tc, err := timecode.NewFromRate(timecode.Rate2997DF, 0)
+		require.NoError(err)
+		require.NoError(tc.Parse(l))
+		g := &GOP{TimeCode: EncodeTimeCode(*tc), ClosedGOP: len(stream) == 0}
+		stream = append(append(stream, newSequenceHeader(4)...), g.Header()...)
+		for i := 0; i < 4; i++ {
+			stream = append(stream, 0, 0, 1, cCodePicture, 0x0F, 0xFF, 0, 0, 0, 0)
+		}
+	}
+	gops, err := Scan(bytes.NewReader(stream))
+	require.NoError(err)
+	require.Len(gops, len(labels))
+	for i, g := range gops {
+		assert.Equal(labels[i], g.Timecode.String(), "sample %d", i+1)
+		assert.Equal(4, g.Pictures, "sample %d", i+1)
+		assert.Equal(i == 0, g.ClosedGOP, "sample %d", i+1)
+		assert.False(g.BrokenLink, "sample %d", i+1)
+		assert.Equal(int64(i*60+12), g.Offset, "sample %d", i+1)
+		assert.Equal(g.Header(), stream[g.Offset:g.Offset+8], "sample %d", i+1)
+	}
+	tcs := Timecodes(gops)
+	require.Len(tcs, 12)
+	assert.Equal("01:00:00;03", tcs[7].String())
+	r := timecode.Analyze(tcs)
+	require.Len(r.Anomalies, 1)
+	assert.Equal(timecode.AnomalyGap, r.Anomalies[0].Kind)
+	assert.Equal(8, r.Anomalies[0].Index)
+	assert.Equal(1, r.Anomalies[0].Delta)
+
+	_, err = Scan(bytes.NewReader(stream[12:]))
+	assert.ErrorIs(err, ErrNoSequenceHeader)
+	_, err = Scan(bytes.NewReader(stream[:18]))
+	assert.ErrorIs(err, ErrInvalidStream)
+	// A reserved frame_rate_code sets the Err of the following GOPs only.
+	reserved := append(newSequenceHeader(9), stream[12:20]...)
+	gops, err = Scan(bytes.NewReader(append(reserved, stream[60:]...)))
+	require.NoError(err)
+	require.Len(gops, 3)
+	assert.ErrorIs(gops[0].Err, ErrUnknownRate)
+	assert.NoError(gops[1].Err)
+	assert.Equal(labels[1], gops[1].Timecode.String())
+	assert.Len(Timecodes(gops), 8)
+	gops, err = Scan(bytes.NewReader(nil))
+	require.NoError(err)
+	assert.Empty(gops)
+
+	// A GOP that does not decode does not stop the scan.
+	stream = newSequenceHeader(7)
+	for _, v := range []uint32{FlagMarker | 1<<13, FlagDropFrame | FlagMarker | 1<<13 | 4,
+		FlagMarker | 1<<13 | 2} {
+		stream = append(stream, (&GOP{TimeCode: v}).Header()...)
+		stream = append(stream, 0, 0, 1, cCodePicture, 0x0F, 0xFF, 0, 0, 0, 0)
+	}
+	gops, err = Scan(bytes.NewReader(stream))
+	require.NoError(err)
+	require.Len(gops, 3)
+	assert.NoError(gops[0].Err)
+	assert.ErrorIs(gops[1].Err, ErrUnsupportedDropFrame)
+	assert.NoError(gops[2].Err)
+	assert.Equal("00:01:00:02", gops[2].Timecode.String())
+	assert.Len(Timecodes(gops), 2)
+}
+
+// newSequenceHeader returns the start of a 720x480 sequence header with the frame rate code `code`.
+func newSequenceHeader(code byte) []byte {
+	return []byte{0, 0, 1, cCodeSequenceHeader, 0x2D, 0x01, 0xE0, 0x20 | code, 0xFF, 0xFF, 0xE0, 0x18}
+}
+
+func Describe(t *testing.T, msg ...string) (*require.Assertions,
+	*assert.Assertions) {
+
+	dispMsg := ""
+	if len(msg) != 0 {
+		dispMsg = msg[0]
+	}
+	name := strings.TrimPrefix(strings.TrimPrefix(t.Name(), "Test"), "_")
+	fmt.Printf("Test %d: %s %s\n", testCounter, name, dispMsg)
+	testCounter++
+	return require.New(t), assert.New(t)
+}
@@ -0,0 +1,380 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+// Package mxf extracts the timecodes of MXF files, i.e., the start timecodes of the Timecode Components
+// of the header metadata and the per frame SMPTE 12M timecodes of the system items, without a full MXF
+// library.  It walks the KLV packets of the file and decodes only the sets that carry timecode.
+//
+// The system items are the CP-compatible system metadata packs of SMPTE 326M and SMPTE 385M.
+package mxf
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/pkg/errors"
+
+	"github.com/wunderbarb/timecode"
+)
+
+var (
+	// ErrInvalidKLV is returned when a KLV packet or a local set is truncated or malformed.
+	ErrInvalidKLV = errors.New("mxf: invalid KLV")
+	// ErrUnknownRate is returned when the rate of a system item cannot be determined.
+	ErrUnknownRate = errors.New("mxf: unknown rate")
+)
+
+// key is a SMPTE universal label.
+type key [cKeySize]byte
+
+var (
+	_keyTimecodeComponent = key{0x06, 0x0E, 0x2B, 0x34, 0x02, 0x53, 0x01, 0x01,
+		0x0D, 0x01, 0x01, 0x01, 0x01, 0x01, 0x14, 0x00}
+	_keySequence = key{0x06, 0x0E, 0x2B, 0x34, 0x02, 0x53, 0x01, 0x01,
+		0x0D, 0x01, 0x01, 0x01, 0x01, 0x01, 0x0F, 0x00}
+	_keyTimelineTrack = key{0x06, 0x0E, 0x2B, 0x34, 0x02, 0x53, 0x01, 0x01,
+		0x0D, 0x01, 0x01, 0x01, 0x01, 0x01, 0x3B, 0x00}
+	_keySystemMetadataPack = key{0x06, 0x0E, 0x2B, 0x34, 0x02, 0x05, 0x01, 0x01,
+		0x0D, 0x01, 0x03, 0x01, 0x04, 0x01, 0x01, 0x00}
+)
+
+// _systemItemRates are the frame rates of the content package rate index of a system item.
+var _systemItemRates = []float64{0, 24, 25, 30, 48, 50, 60, 72, 75, 90, 96, 100, 120}
+
+// The local tags of the header metadata sets.
+const (
+	cTagInstanceUID          = 0x3C0A
+	cTagStructuralComponents = 0x1001
+	cTagStartTimecode        = 0x1501
+	cTagRoundedTimecodeBase  = 0x1502
+	cTagDropFrame            = 0x1503
+	cTagSequence             = 0x4803
+	cTagEditRate             = 0x4B01
+)
+
+const (
+	cKeySize     = 16
+	cVersionByte = 7
+	cUIDSize     = 16
+	cLocalHeader = 4
+	cBatchHeader = 8
+	cMaxBERBytes = 8
+	// cMaxSetSize is the largest decoded set or system metadata pack.  Real ones are at most a few KB.
+	cMaxSetSize    = 1 << 20
+	cBERLong       = 0x80
+	cRateByte      = 1
+	cRateNTSC      = 0x01
+	cCreationStamp = 23
+	cUserStamp     = 40
+	cStampSize     = 17
+	cStamp12M      = 0x81
+)
+
+// Rational is a rational number `Num`/`Den`.
+type Rational struct {
+	Num int32
+	Den int32
+}
+
+// Component is a Timecode Component of the header metadata.
+type Component struct {
+	// RoundedTimecodeBase is the nominal number of frames per second, e.g., 30 at 29.97 FPS.
+	RoundedTimecodeBase uint16
+	// StartTimecode is the frame count of the start timecode.
+	StartTimecode int64
+	// DropFrame is true if the timecode is drop frame.
+	DropFrame bool
+	// EditRate is the edit rate of the track of the component or zero if the track is unknown.
+	EditRate Rational
+	// Start is the start timecode at the rate returned by Rate.  It is the zero value if Err is not nil.
+	Start timecode.Timecode
+	// Err is the error of the decoding of the start timecode or nil.
+	Err error
+	uid [cUIDSize]byte
+}
+
+// File is the timecode information of an MXF file.
+type File struct {
+	// Components are the Timecode Components of the header metadata in file order.  A component repeated
+	// by several partitions is listed once.
+	Components []Component
+	// Timecodes are the timecodes of the system items in file order, i.e., one per content package.  The
+	// system items that do not decode are skipped.
+	Timecodes []timecode.Timecode
+	// Errs are the errors of the system items that do not decode with their rank in the file.
+	Errs []error
+}
+
+// decoder holds the sets and system items read so far.
+type decoder struct {
+	components []Component
+	// sequences maps the uid of a sequence to the uids of its components.
+	sequences map[[cUIDSize]byte][][cUIDSize]byte
+	// tracks maps the uid of the sequence of a track to the edit rate of the track.
+	tracks map[[cUIDSize]byte]Rational
+	items  []systemItem
+}
+
+// systemItem is the SMPTE 12M timecode of a system item and its content package rate.
+type systemItem struct {
+	rate byte
+	v    uint32
+}
+
+// Read reads the KLV packets of the MXF file read from `r` and returns its timecodes.  A component or a
+// system item whose timecode does not decode, e.g., at an unsupported rate, does not stop the reading; its
+// error is recorded in the file.
+func Read(r io.Reader) (*File, error) {
+	rd := bufio.NewReader(r)
+	d := &decoder{sequences: map[[cUIDSize]byte][][cUIDSize]byte{}, tracks: map[[cUIDSize]byte]Rational{}}
+	for {
+		var k key
+		if _, err := io.ReadFull(rd, k[:]); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Wrapf(ErrInvalidKLV, "key: %v", err)
+		}
+		n, err := readBER(rd)
+		if err != nil {
+			return nil, err
+		}
+		if !k.isAny(_keyTimecodeComponent, _keySequence, _keyTimelineTrack, _keySystemMetadataPack) {
+			if _, err := io.CopyN(io.Discard, rd, n); err != nil {
+				return nil, errors.Wrapf(ErrInvalidKLV, "value of %d bytes: %v", n, err)
+			}
+			continue
+		}
+		if n > cMaxSetSize {
+			return nil, errors.Wrapf(ErrInvalidKLV, "set of %d bytes", n)
+		}
+		v := make([]byte, n)
+		if _, err := io.ReadFull(rd, v); err != nil {
+			return nil, errors.Wrapf(ErrInvalidKLV, "value of %d bytes: %v", n, err)
+		}
+		if err := d.decode(k, v); err != nil {
+			return nil, err
+		}
+	}
+	return d.file()
+}
+
+// Rate returns the timecode rate of the component.  It is the edit rate of its track, snapped as by
+// timecode.SnapNTSC, if it rounds to the rounded timecode base.  Otherwise, it is the rounded timecode base,
+// or its NTSC rate in drop frame.
+func (c *Component) Rate() (timecode.Rate, error) {
+	base := float64(c.RoundedTimecodeBase)
+	r := timecode.Rate{FPS: base, DropFrame: c.DropFrame}
+	switch {
+	case c.EditRate.Num > 0 && c.EditRate.Den > 0 &&
+		math.Round(float64(c.EditRate.Num)/float64(c.EditRate.Den)) == base:
+		r.FPS = timecode.SnapNTSC(float64(c.EditRate.Num) / float64(c.EditRate.Den))
+	case c.DropFrame:
+		r.FPS = timecode.NTSCRate(base)
+	}
+	if !r.Valid() {
+		return timecode.Rate{}, errors.Wrapf(timecode.ErrInvalidFPS, "base %d drop frame %v",
+			c.RoundedTimecodeBase, c.DropFrame)
+	}
+	return r, nil
+}
+
+// decode decodes the value `v` of the KLV packet of key `k`.
+func (d *decoder) decode(k key, v []byte) error {
+	if k.is(_keySystemMetadataPack) {
+		d.decodeSystemItem(v)
+		return nil
+	}
+	items, err := localSet(v)
+	if err != nil {
+		return err
+	}
+	var uid [cUIDSize]byte
+	copy(uid[:], items[cTagInstanceUID])
+	switch {
+	case k.is(_keyTimecodeComponent):
+		for _, c := range d.components {
+			if c.uid == uid {
+				return nil
+			}
+		}
+		c := Component{uid: uid}
+		if b := items[cTagRoundedTimecodeBase]; len(b) == 2 {
+			c.RoundedTimecodeBase = binary.BigEndian.Uint16(b)
+		}
+		if b := items[cTagStartTimecode]; len(b) == 8 {
+			c.StartTimecode = int64(binary.BigEndian.Uint64(b))
+		}
+		if b := items[cTagDropFrame]; len(b) == 1 {
+			c.DropFrame = b[0] != 0
+		}
+		d.components = append(d.components, c)
+	case k.is(_keySequence):
+		b := items[cTagStructuralComponents]
+		if len(b) < cBatchHeader {
+			return nil
+		}
+		count, size := binary.BigEndian.Uint32(b), binary.BigEndian.Uint32(b[4:])
+		if size != cUIDSize || uint64(len(b)-cBatchHeader) < uint64(count)*cUIDSize {
+			return errors.Wrapf(ErrInvalidKLV, "batch of %d items of %d bytes", count, size)
+		}
+		var refs [][cUIDSize]byte
+		for i := 0; i < int(count); i++ {
+			var ref [cUIDSize]byte
+			copy(ref[:], b[cBatchHeader+i*cUIDSize:])
+			refs = append(refs, ref)
+		}
+		d.sequences[uid] = refs
+	case k.is(_keyTimelineTrack):
+		b, seq := items[cTagEditRate], items[cTagSequence]
+		if len(b) != 8 || len(seq) != cUIDSize {
+			return nil
+		}
+		var ref [cUIDSize]byte
+		copy(ref[:], seq)
+		d.tracks[ref] = Rational{Num: int32(binary.BigEndian.Uint32(b)), Den: int32(binary.BigEndian.Uint32(b[4:]))}
+	}
+	return nil
+}
+
+// decodeSystemItem records the SMPTE 12M timecode of the user date/time stamp of the system item or else
+// of its creation date/time stamp.  System items without timecode are ignored.
+func (d *decoder) decodeSystemItem(v []byte) {
+	for _, off := range []int{cUserStamp, cCreationStamp} {
+		if len(v) >= off+cStampSize && v[off] == cStamp12M {
+			d.items = append(d.items, systemItem{rate: v[cRateByte], v: binary.LittleEndian.Uint32(v[off+1:])})
+			return
+		}
+	}
+}
+
+// file returns the file with the edit rates of the components and the timecodes of the system items.
+func (d *decoder) file() (*File, error) {
+	f := &File{Components: d.components}
+	for i := range f.Components {
+		c := &f.Components[i]
+		c.EditRate = d.editRate(c.uid)
+		r, err := c.Rate()
+		if err != nil {
+			c.Err = err
+			continue
+		}
+		start, err := timecode.NewFromRate(r, int(c.StartTimecode))
+		if err != nil {
+			c.Err = errors.Wrapf(err, "start timecode %d", c.StartTimecode)
+			continue
+		}
+		c.Start = *start
+	}
+	for i, it := range d.items {
+		r, err := d.itemRate(it.rate)
+		if err != nil {
+			f.Errs = append(f.Errs, errors.Wrapf(err, "system item %d", i+1))
+			continue
+		}
+		tc, err := timecode.NewFromSMPTE12M(r, it.v)
+		if err != nil {
+			f.Errs = append(f.Errs, errors.Wrapf(err, "system item %d: %08X", i+1, it.v))
+			continue
+		}
+		f.Timecodes = append(f.Timecodes, *tc)
+	}
+	return f, nil
+}
+
+// editRate returns the edit rate of the track that refers to the component `uid` directly or through a
+// sequence.
+func (d *decoder) editRate(uid [cUIDSize]byte) Rational {
+	if r, ok := d.tracks[uid]; ok {
+		return r
+	}
+	for seq, refs := range d.sequences {
+		for _, ref := range refs {
+			if ref == uid {
+				return d.tracks[seq]
+			}
+		}
+	}
+	return Rational{}
+}
+
+// itemRate returns the rate of the content package rate `b` of a system item or else the rate of the
+// first Timecode Component.
+func (d *decoder) itemRate(b byte) (timecode.Rate, error) {
+	if i := int(b >> 1); i > 0 && i < len(_systemItemRates) {
+		fps := _systemItemRates[i]
+		if b&cRateNTSC != 0 {
+			fps = timecode.NTSCRate(fps)
+		}
+		return timecode.Rate{FPS: fps}, nil
+	}
+	for _, c := range d.components {
+		if c.RoundedTimecodeBase > 0 {
+			return c.Rate()
+		}
+	}
+	return timecode.Rate{}, ErrUnknownRate
+}
+
+// localSet returns the values of the local set `v` by local tag.
+func localSet(v []byte) (map[uint16][]byte, error) {
+	items := map[uint16][]byte{}
+	for len(v) > 0 {
+		if len(v) < cLocalHeader {
+			return nil, errors.Wrapf(ErrInvalidKLV, "local set item of %d bytes", len(v))
+		}
+		tag, n := binary.BigEndian.Uint16(v), int(binary.BigEndian.Uint16(v[2:]))
+		if len(v) < cLocalHeader+n {
+			return nil, errors.Wrapf(ErrInvalidKLV, "local tag %04X of %d bytes", tag, n)
+		}
+		items[tag] = v[cLocalHeader : cLocalHeader+n]
+		v = v[cLocalHeader+n:]
+	}
+	return items, nil
+}
+
+// readBER reads a BER encoded length.
+func readBER(r io.ByteReader) (int64, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return 0, errors.Wrapf(ErrInvalidKLV, "length: %v", err)
+	}
+	if b < cBERLong {
+		return int64(b), nil
+	}
+	n := int(b &^ cBERLong)
+	if n == 0 || n > cMaxBERBytes {
+		return 0, errors.Wrapf(ErrInvalidKLV, "length of %d bytes", n)
+	}
+	var l uint64
+	for i := 0; i < n; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, errors.Wrapf(ErrInvalidKLV, "length: %v", err)
+		}
+		l = l<<8 | uint64(b)
+	}
+	if l > math.MaxInt64 {
+		return 0, errors.Wrapf(ErrInvalidKLV, "length %d", l)
+	}
+	return int64(l), nil
+}
+
+// is returns true if the key is `other` regardless of the version byte.
+func (k key) is(other key) bool {
+	return bytes.Equal(k[:cVersionByte], other[:cVersionByte]) &&
+		bytes.Equal(k[cVersionByte+1:], other[cVersionByte+1:])
+}
+
+// isAny returns true if the key is one of `others` regardless of the version byte.
+func (k key) isAny(others ...key) bool {
+	for _, o := range others {
+		if k.is(o) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,188 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package mxf
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wunderbarb/timecode"
+)
+
+var testCounter int
+
+func TestRead(t *testing.T) {
+	require, assert := Describe(t)
+
+	data, err := os.ReadFile("testdata/op1a.mxf")
+	require.NoError(err)
+	f, err := Read(bytes.NewReader(data))
+	require.NoError(err)
+	require.Len(f.Components, 2)
+	c := f.Components[0]
+	assert.Equal(uint16(30), c.RoundedTimecodeBase)
+	assert.Equal(int64(107892), c.StartTimecode)
+	assert.True(c.DropFrame)
+	assert.Equal(Rational{Num: 30000, Den: 1001}, c.EditRate)
+	assert.Equal("01:00:00;00", c.Start.String())
+	assert.Equal(timecode.Rate2997DF, c.Start.Rate())
+	c = f.Components[1]
+	assert.Equal(Rational{Num: 25, Den: 1}, c.EditRate)
+	assert.Equal("10:00:00:00", c.Start.String())
+
+	require.Len(f.Timecodes, 3)
+	for i, tc := range f.Timecodes {
+		assert.Equal(fmt.Sprintf("01:00:00;%02d", i), tc.String(), "sample %d", i+1)
+		assert.Equal(timecode.Rate2997DF, tc.Rate(), "sample %d", i+1)
+	}
+
+	_, err = Read(bytes.NewReader(data[:len(data)-3]))
+	assert.ErrorIs(err, ErrInvalidKLV)
+	_, err = Read(bytes.NewReader(append(bytes.Clone(data[:cKeySize]), 0x80)))
+	assert.ErrorIs(err, ErrInvalidKLV)
+	// Malformed lengths of decoded and skipped packets.
+	for i, k := range []key{_keyTimecodeComponent, {0x06, 0x0E, 0x2B, 0x34}} {
+		_, err = Read(bytes.NewReader(append(k[:], 0x88, 0x7F, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 1)))
+		assert.ErrorIs(err, ErrInvalidKLV, "sample %d", i+1)
+	}
+	f, err = Read(bytes.NewReader(nil))
+	require.NoError(err)
+	assert.Empty(f.Components)
+}
+
+func TestComponent_Rate(t *testing.T) {
+	_, assert := Describe(t)
+
+	tests := []struct {
+		c       Component
+		want    timecode.Rate
+		wantErr bool
+	}{
+		{Component{RoundedTimecodeBase: 25}, timecode.Rate25, false},
+		{Component{RoundedTimecodeBase: 30, DropFrame: true}, timecode.Rate2997DF, false},
+		{Component{RoundedTimecodeBase: 24, EditRate: Rational{24000, 1001}}, timecode.Rate23976, false},
+		{Component{RoundedTimecodeBase: 30, EditRate: Rational{2997, 100}}, timecode.Rate2997, false},
+		{Component{RoundedTimecodeBase: 25, EditRate: Rational{50, 1}}, timecode.Rate25, false},
+		{Component{RoundedTimecodeBase: 0}, timecode.Rate{}, true},
+		{Component{RoundedTimecodeBase: 25, DropFrame: true}, timecode.Rate{}, true},
+	}
+	for i, tt := range tests {
+		r, err := tt.c.Rate()
+		assert.Equal(tt.wantErr, err != nil, "sample %d", i+1)
+		assert.Equal(tt.want, r, "sample %d", i+1)
+	}
+}
+
+func TestDecoder_itemRate(t *testing.T) {
+	_, assert := Describe(t)
+
+	d := &decoder{}
+	tests := []struct {
+		b       byte
+		want    timecode.Rate
+		wantErr bool
+	}{
+		{2 << 1, timecode.Rate25, false},
+		{3<<1 | cRateNTSC, timecode.Rate2997, false},
+		{1<<1 | cRateNTSC, timecode.Rate23976, false},
+		{6 << 1, timecode.Rate{FPS: 60}, false},
+		{0, timecode.Rate{}, true},
+		{15 << 1, timecode.Rate{}, true},
+	}
+	for i, tt := range tests {
+		r, err := d.itemRate(tt.b)
+		assert.Equal(tt.wantErr, err != nil, "sample %d", i+1)
+		assert.Equal(tt.want, r, "sample %d", i+1)
+	}
+	d.components = []Component{{RoundedTimecodeBase: 24}}
+	r, err := d.itemRate(0)
+	assert.NoError(err)
+	assert.Equal(timecode.Rate24, r)
+}
+
+func TestDecoder_file(t *testing.T) {
+	require, assert := Describe(t)
+
+	// A component or a system item that does not decode does not stop the others.
+	d := &decoder{
+		components: []Component{
+			{RoundedTimecodeBase: 60, DropFrame: true},
+			{RoundedTimecodeBase: 25, StartTimecode: 90000},
+		},
+		items: []systemItem{
+			{rate: 2 << 1, v: 0x01000000},
+			{rate: 0, v: 0x01000000},
+			{rate: 2 << 1, v: 0x0100001A},
+			{rate: 2 << 1, v: 0x01000001},
+		},
+	}
+	f, err := d.file()
+	require.NoError(err)
+	require.Len(f.Components, 2)
+	assert.ErrorIs(f.Components[0].Err, timecode.ErrInvalidFPS)
+	assert.NoError(f.Components[1].Err)
+	assert.Equal("01:00:00:00", f.Components[1].Start.String())
+	require.Len(f.Timecodes, 2)
+	assert.Equal("01:00:00:00", f.Timecodes[0].String())
+	assert.Equal("01:00:00:01", f.Timecodes[1].String())
+	require.Len(f.Errs, 2)
+	assert.ErrorContains(f.Errs[0], "system item 2")
+	assert.ErrorIs(f.Errs[1], timecode.ErrInvalidTimeCode)
+}
+
+func TestLocalSet(t *testing.T) {
+	require, assert := Describe(t)
+
+	items, err := localSet([]byte{0x15, 0x02, 0x00, 0x02, 0x00, 0x19, 0x15, 0x03, 0x00, 0x01, 0x01})
+	require.NoError(err)
+	assert.Equal([]byte{0x00, 0x19}, items[cTagRoundedTimecodeBase])
+	assert.Equal([]byte{0x01}, items[cTagDropFrame])
+	_, err = localSet([]byte{0x15, 0x02, 0x00, 0x04, 0x00})
+	assert.ErrorIs(err, ErrInvalidKLV)
+	_, err = localSet([]byte{0x15})
+	assert.ErrorIs(err, ErrInvalidKLV)
+}
+
+func TestReadBER(t *testing.T) {
+	_, assert := Describe(t)
+
+	tests := []struct {
+		b       []byte
+		want    int64
+		wantErr bool
+	}{
+		{[]byte{0x05}, 5, false},
+		{[]byte{0x81, 0xC8}, 200, false},
+		{[]byte{0x83, 0x01, 0x00, 0x00}, 65536, false},
+		{[]byte{0x80}, 0, true},
+		{[]byte{0x89, 1, 2, 3, 4, 5, 6, 7, 8, 9}, 0, true},
+		{[]byte{0x82, 0x01}, 0, true},
+		{[]byte{0x88, 0xFF, 0, 0, 0, 0, 0, 0, 0}, 0, true},
+	}
+	for i, tt := range tests {
+		n, err := readBER(bytes.NewReader(tt.b))
+		assert.Equal(tt.wantErr, err != nil, "sample %d", i+1)
+		assert.Equal(tt.want, n, "sample %d", i+1)
+	}
+}
+
+func Describe(t *testing.T, msg ...string) (*require.Assertions,
+	*assert.Assertions) {
+
+	dispMsg := ""
+	if len(msg) != 0 {
+		dispMsg = msg[0]
+	}
+	name := strings.TrimPrefix(strings.TrimPrefix(t.Name(), "Test"), "_")
+	fmt.Printf("Test %d: %s %s\n", testCounter, name, dispMsg)
+	testCounter++
+	return require.New(t), assert.New(t)
+}
@@ -0,0 +1,292 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+// Package otio converts timecodes from and to the RationalTime and TimeRange of OpenTimelineIO.  It
+// reads and writes the source ranges of the clips of `.otio` documents without the OpenTimelineIO library.
+//
+// As OpenTimelineIO, it detects drop frame timecode strings by the `;` separator and infers drop frame
+// from the 29.97 FPS rate unless told otherwise.
+package otio
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/wunderbarb/timecode"
+)
+
+// ErrInvalidSchema is returned when a JSON object does not have the expected OTIO_SCHEMA.
+var ErrInvalidSchema = errors.New("otio: invalid schema")
+
+const (
+	cSchema             = "OTIO_SCHEMA"
+	cRationalTimeSchema = "RationalTime.1"
+	cTimeRangeSchema    = "TimeRange.1"
+	cClipSchema         = "Clip."
+	cSourceRange        = "source_range"
+)
+
+// RationalTime is a point in time as a number of frames `Value` at the rate `Rate`.
+type RationalTime struct {
+	Value float64
+	Rate  float64
+}
+
+// TimeRange is a span of time starting at `StartTime` and lasting `Duration`.
+type TimeRange struct {
+	StartTime RationalTime
+	Duration  RationalTime
+}
+
+// DropFrameMode selects the drop frame of the timecode strings.
+type DropFrameMode int
+
+const (
+	// InferFromRate uses drop frame at 29.97 FPS.
+	InferFromRate DropFrameMode = iota
+	// ForceYes always uses drop frame.
+	ForceYes
+	// ForceNo never uses drop frame.
+	ForceNo
+)
+
+type rationalTimeJSON struct {
+	Schema string `json:"OTIO_SCHEMA"`
+	Rate   double `json:"rate"`
+	Value  double `json:"value"`
+}
+
+// double is a float64 that is always written with a decimal point, e.g., `24.0`, as OpenTimelineIO does.
+type double float64
+
+type timeRangeJSON struct {
+	Schema    string       `json:"OTIO_SCHEMA"`
+	Duration  RationalTime `json:"duration"`
+	StartTime RationalTime `json:"start_time"`
+}
+
+// NewRationalTime returns the rational time of the timecode `tc`.
+func NewRationalTime(tc timecode.Timecode) RationalTime {
+	return RationalTime{Value: float64(tc.Frame()), Rate: tc.Rate().FPS}
+}
+
+// FromTimecode returns the rational time of the timecode string `s` at the rate `rate`.  The string is
+// drop frame if it contains `;`.
+func FromTimecode(s string, rate float64) (RationalTime, error) {
+	r := timecode.Rate{FPS: timecode.SnapNTSC(rate), DropFrame: strings.Contains(s, ";")}
+	tc, err := timecode.NewFromRate(r, 0)
+	if err != nil {
+		return RationalTime{}, err
+	}
+	if err := tc.Parse(s); err != nil {
+		return RationalTime{}, err
+	}
+	return RationalTime{Value: float64(tc.Frame()), Rate: rate}, nil
+}
+
+// Timecode returns the timecode of the rational time.  The value is rounded to the nearest frame.
+// Rates close to an NTSC rate, e.g., 29.97 or 23.976, are snapped to it as by timecode.SnapNTSC.
+func (rt RationalTime) Timecode(dropFrame bool) (*timecode.Timecode, error) {
+	r := timecode.Rate{FPS: timecode.SnapNTSC(rt.Rate), DropFrame: dropFrame}
+	return timecode.NewFromRate(r, int(math.Round(rt.Value)))
+}
+
+// ToTimecode returns the timecode string of the rational time, e.g., `01:00:00;00`.
+func (rt RationalTime) ToTimecode(mode DropFrameMode) (string, error) {
+	rate := timecode.SnapNTSC(rt.Rate)
+	df := mode == ForceYes || (mode == InferFromRate && rate == timecode.FPS2997)
+	tc, err := rt.Timecode(df)
+	if err != nil {
+		return "", err
+	}
+	return tc.String(), nil
+}
+
+// Rescaled returns the rational time at the rate `rate`.
+func (rt RationalTime) Rescaled(rate float64) RationalTime {
+	if rt.Rate == rate {
+		return rt
+	}
+	return RationalTime{Value: rt.Value * rate / rt.Rate, Rate: rate}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (rt RationalTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(rationalTimeJSON{Schema: cRationalTimeSchema, Rate: double(rt.Rate),
+		Value: double(rt.Value)})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (rt *RationalTime) UnmarshalJSON(b []byte) error {
+	var v rationalTimeJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	if !hasSchema(v.Schema, cRationalTimeSchema) {
+		return errors.Wrapf(ErrInvalidSchema, "%q", v.Schema)
+	}
+	*rt = RationalTime{Value: float64(v.Value), Rate: float64(v.Rate)}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (f double) MarshalJSON() ([]byte, error) {
+	if math.IsInf(float64(f), 0) || math.IsNaN(float64(f)) {
+		return nil, errors.Errorf("otio: unsupported value %v", float64(f))
+	}
+	s := strconv.FormatFloat(float64(f), 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return []byte(s), nil
+}
+
+// NewTimeRange returns the time range of the timecode range `r`.
+func NewTimeRange(r timecode.Range) TimeRange {
+	start := NewRationalTime(r.Start)
+	return TimeRange{StartTime: start, Duration: RationalTime{Value: float64(r.Duration), Rate: start.Rate}}
+}
+
+// Range returns the timecode range of the time range.  The duration is rescaled to the rate of the
+// start time.
+func (tr TimeRange) Range(dropFrame bool) (timecode.Range, error) {
+	start, err := tr.StartTime.Timecode(dropFrame)
+	if err != nil {
+		return timecode.Range{}, err
+	}
+	d := int(math.Round(tr.Duration.Rescaled(tr.StartTime.Rate).Value))
+	if d < 0 {
+		return timecode.Range{}, errors.Wrapf(timecode.ErrInvalidRange, "duration %v", d)
+	}
+	return timecode.Range{Start: *start, Duration: d}, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (tr TimeRange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(timeRangeJSON{Schema: cTimeRangeSchema, Duration: tr.Duration, StartTime: tr.StartTime})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (tr *TimeRange) UnmarshalJSON(b []byte) error {
+	var v timeRangeJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	if !hasSchema(v.Schema, cTimeRangeSchema) {
+		return errors.Wrapf(ErrInvalidSchema, "%q", v.Schema)
+	}
+	*tr = TimeRange{StartTime: v.StartTime, Duration: v.Duration}
+	return nil
+}
+
+// Document is an OpenTimelineIO document.  The fields that are not clip source ranges are preserved.
+type Document struct {
+	root  interface{}
+	clips []*Clip
+}
+
+// Clip is a clip of a document.
+type Clip struct {
+	// Name is the name of the clip.
+	Name string
+	// SourceRange is the source range of the clip or nil if it has none.
+	SourceRange *TimeRange
+	obj         map[string]interface{}
+}
+
+// Read reads an OpenTimelineIO document.
+func Read(r io.Reader) (*Document, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	d := &Document{}
+	if err := dec.Decode(&d.root); err != nil {
+		return nil, err
+	}
+	if err := d.collect(d.root); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Clips returns the clips of the document in depth-first order.
+func (d *Document) Clips() []*Clip {
+	return d.clips
+}
+
+// Write writes the document with the current source ranges of its clips.
+func (d *Document) Write(w io.Writer) error {
+	for _, c := range d.clips {
+		if c.SourceRange == nil {
+			c.obj[cSourceRange] = nil
+			continue
+		}
+		var v interface{}
+		if err := remarshal(c.SourceRange, &v); err != nil {
+			return err
+		}
+		c.obj[cSourceRange] = v
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	return enc.Encode(d.root)
+}
+
+// collect appends the clips found in `v` to the clips of the document.
+func (d *Document) collect(v interface{}) error {
+	switch o := v.(type) {
+	case map[string]interface{}:
+		if s, ok := o[cSchema].(string); ok && strings.HasPrefix(s, cClipSchema) {
+			c := &Clip{obj: o}
+			c.Name, _ = o["name"].(string)
+			if sr, ok := o[cSourceRange]; ok && sr != nil {
+				c.SourceRange = &TimeRange{}
+				if err := remarshal(sr, c.SourceRange); err != nil {
+					return errors.Wrapf(err, "clip %q", c.Name)
+				}
+			}
+			d.clips = append(d.clips, c)
+		}
+		keys := make([]string, 0, len(o))
+		for k := range o {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if err := d.collect(o[k]); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, e := range o {
+			if err := d.collect(e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// remarshal converts `src` to `dst` through JSON.
+func remarshal(src interface{}, dst interface{}) error {
+	b, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	return dec.Decode(dst)
+}
+
+// hasSchema returns true if the schema `s` is empty or has the name of `expected`.
+func hasSchema(s string, expected string) bool {
+	name, _, _ := strings.Cut(expected, ".")
+	return s == "" || strings.HasPrefix(s, name+".")
+}
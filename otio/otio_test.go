@@ -0,0 +1,155 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package otio
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wunderbarb/timecode"
+)
+
+var testCounter int
+
+func TestRationalTime_JSON(t *testing.T) {
+	require, assert := Describe(t)
+
+	tc, _ := timecode.NewFromRate(timecode.Rate24, 86400)
+	b, err := json.Marshal(NewRationalTime(*tc))
+	require.NoError(err)
+	assert.Equal(`{"OTIO_SCHEMA":"RationalTime.1","rate":24.0,"value":86400.0}`, string(b))
+
+	var rt RationalTime
+	require.NoError(json.Unmarshal([]byte(`{"OTIO_SCHEMA":"RationalTime.1","rate":29.97,"value":1800}`), &rt))
+	assert.Equal(RationalTime{Value: 1800, Rate: 29.97}, rt)
+	tc, err = rt.Timecode(true)
+	require.NoError(err)
+	assert.Equal("00:01:00;02", tc.String())
+	assert.Error(json.Unmarshal([]byte(`{"OTIO_SCHEMA":"TimeRange.1"}`), &rt))
+	assert.Error(json.Unmarshal([]byte(`[]`), &rt))
+}
+
+func TestTimeRange(t *testing.T) {
+	require, assert := Describe(t)
+
+	start, _ := timecode.NewWithDropFrameFromString("01:00:00;00")
+	r := timecode.Range{Start: *start, Duration: 48}
+	tr := NewTimeRange(r)
+	b, err := json.Marshal(tr)
+	require.NoError(err)
+	assert.Equal(`{"OTIO_SCHEMA":"TimeRange.1","duration":{"OTIO_SCHEMA":"RationalTime.1",`+
+		`"rate":29.97002997002997,"value":48.0},"start_time":{"OTIO_SCHEMA":"RationalTime.1",`+
+		`"rate":29.97002997002997,"value":107892.0}}`, string(b))
+
+	var tr1 TimeRange
+	require.NoError(json.Unmarshal(b, &tr1))
+	r1, err := tr1.Range(true)
+	require.NoError(err)
+	assert.Equal(r.String(), r1.String())
+
+	tr1.Duration = RationalTime{Value: 48000, Rate: 48000}
+	r1, err = tr1.Range(true)
+	require.NoError(err)
+	assert.Equal(30, r1.Duration)
+	tr1.Duration.Value = -48000
+	_, err = tr1.Range(true)
+	assert.ErrorIs(err, timecode.ErrInvalidRange)
+	assert.Error(json.Unmarshal([]byte(`{"OTIO_SCHEMA":"Clip.2"}`), &tr1))
+}
+
+func TestToTimecode(t *testing.T) {
+	require, assert := Describe(t)
+
+	tests := []struct {
+		rt         RationalTime
+		mode       DropFrameMode
+		exp        string
+		expSuccess bool
+	}{
+		{RationalTime{Value: 107892, Rate: 30000.0 / 1001}, InferFromRate, "01:00:00;00", true},
+		{RationalTime{Value: 107892, Rate: 29.97}, ForceNo, "00:59:56:12", true},
+		{RationalTime{Value: 86400, Rate: 24}, InferFromRate, "01:00:00:00", true},
+		{RationalTime{Value: 86400, Rate: 23.976}, InferFromRate, "01:00:00:00", true},
+		{RationalTime{Value: 86400, Rate: 24}, ForceYes, "", false},
+	}
+	for i, tt := range tests {
+		s, err := tt.rt.ToTimecode(tt.mode)
+		require.Equal(tt.expSuccess, err == nil, "sample %d", i+1)
+		assert.Equal(tt.exp, s, "sample %d", i+1)
+	}
+
+	rt, err := FromTimecode("01:00:00;00", 30000.0/1001)
+	require.NoError(err)
+	assert.Equal(107892.0, rt.Value)
+	rt, err = FromTimecode("01:00:00:00", 30000.0/1001)
+	require.NoError(err)
+	assert.Equal(108000.0, rt.Value)
+	_, err = FromTimecode("01:00:00;00", 25)
+	assert.ErrorIs(err, timecode.ErrInvalidFPS)
+	rt, err = FromTimecode("00:01:00;02", 29.97)
+	require.NoError(err)
+	assert.Equal(1800.0, rt.Value)
+	_, err = FromTimecode("01:00:00", 25)
+	assert.ErrorIs(err, timecode.ErrInvalidTimeCode)
+}
+
+func TestDocument(t *testing.T) {
+	require, assert := Describe(t)
+
+	f, err := os.Open("testdata/timeline.otio")
+	require.NoError(err)
+	defer f.Close()
+	d, err := Read(f)
+	require.NoError(err)
+	clips := d.Clips()
+	require.Len(clips, 2)
+	assert.Equal("A001C003", clips[0].Name)
+	require.NotNil(clips[0].SourceRange)
+	r, err := clips[0].SourceRange.Range(true)
+	require.NoError(err)
+	assert.Equal("00:01:00;02-00:01:01;20", r.String())
+	assert.Nil(clips[1].SourceRange)
+
+	r.Duration = 24
+	tr := NewTimeRange(r)
+	clips[1].SourceRange = &tr
+	var buf bytes.Buffer
+	require.NoError(d.Write(&buf))
+	out := buf.String()
+	assert.Contains(out, `"camera": "A"`)
+	assert.Contains(out, `"value": 107892.0`)
+	d1, err := Read(&buf)
+	require.NoError(err)
+	require.Len(d1.Clips(), 2)
+	r1, err := d1.Clips()[1].SourceRange.Range(true)
+	require.NoError(err)
+	assert.Equal("00:01:00;02-00:01:00;26", r1.String())
+
+	_, err = Read(strings.NewReader(`{"OTIO_SCHEMA":"Clip.2","source_range":{"OTIO_SCHEMA":"Gap.1"}}`))
+	assert.ErrorIs(err, ErrInvalidSchema)
+}
+
+// Describe displays the rank of the test, the name of the function
+// and its optional description provided by 'msg'.  It initializes an assert
+// and a require function and returns them.
+func Describe(t *testing.T, msg ...string) (*require.Assertions,
+	*assert.Assertions) {
+
+	dispMsg := ""
+	if len(msg) != 0 {
+		dispMsg = msg[0]
+	}
+	name := strings.TrimPrefix(strings.TrimPrefix(t.Name(), "Test"), "_")
+	fmt.Printf("Test %d: %s %s\n", testCounter, name, dispMsg)
+	testCounter++
+	return require.New(t), assert.New(t)
+}
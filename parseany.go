@@ -0,0 +1,146 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Form is the form of a timecode string recognized by ParseAny.
+type Form int
+
+const (
+	// FormSMPTE is the strict form HH:MM:SS:FF or HH:MM:SS;FF accepted by Parse.
+	FormSMPTE Form = iota
+	// FormUnpadded is HH:MM:SS:FF with unpadded fields, e.g., 1:02:03:04.
+	FormUnpadded
+	// FormDotted is HH.MM.SS.FF.
+	FormDotted
+	// FormCompact is HHMMSS:FF.
+	FormCompact
+	// FormFrames is a bare frame count, e.g., 86400.
+	FormFrames
+	// FormSeconds is a number of seconds followed by `s`, e.g., 123.45s.
+	FormSeconds
+	// FormMilliseconds is HH:MM:SS,mmm or HH:MM:SS.mmm, i.e., SubRip or WebVTT.
+	FormMilliseconds
+)
+
+var (
+	_reUnpadded = regexp.MustCompile(`^(\d{1,2}):(\d{1,2}):(\d{1,2})([:;.])(\d{1,2})$`)
+	_reDotted   = regexp.MustCompile(`^(\d{1,2})\.(\d{2})\.(\d{2})\.(\d{2})$`)
+	_reCompact  = regexp.MustCompile(`^(\d{2})(\d{2})(\d{2})([:;.])(\d{2})$`)
+	_reFrames   = regexp.MustCompile(`^\d+$`)
+	_reSeconds  = regexp.MustCompile(`^\d+(\.\d+)?s$`)
+)
+
+// String returns the name of the form.
+func (f Form) String() string {
+	switch f {
+	case FormSMPTE:
+		return "SMPTE"
+	case FormUnpadded:
+		return "unpadded"
+	case FormDotted:
+		return "dotted"
+	case FormCompact:
+		return "compact"
+	case FormFrames:
+		return "frames"
+	case FormSeconds:
+		return "seconds"
+	case FormMilliseconds:
+		return "milliseconds"
+	default:
+		return fmt.Sprintf("Form(%d)", int(f))
+	}
+}
+
+// ParseAny leniently parses the timecode string `s` with the frame rate `fps` and returns the timecode
+// and the form that matched.  It recognizes, in this order, the strict form of Parse, `1:02:03:04`,
+// `01.02.03.04`, `010203:04`, the bare frame counts `86400`, the seconds `123.45s` and the timestamps
+// `00:01:02,345` and `00:01:02.345`.
+//
+// The drop frame is detected from the separator before the frames.  `;` selects drop frame.  `.` after
+// `:` separators selects drop frame at 29.97 FPS.  Drop frame requires 29.97 FPS.  The leading and
+// trailing spaces are ignored.
+//
+// Parse remains strict and should be used for validation.
+func ParseAny(fps float64, s string) (*Timecode, Form, error) {
+	if fps <= 0.0 {
+		return nil, FormSMPTE, ErrInvalidFPS
+	}
+	s = strings.TrimSpace(s)
+	switch {
+	case _reTimecode.MatchString(s):
+		tc, err := parseFields(fps, s, []string{s[0:2], s[3:5], s[6:8], s[8:9], s[9:11]})
+		return tc, FormSMPTE, err
+	case _reUnpadded.MatchString(s):
+		tc, err := parseFields(fps, s, _reUnpadded.FindStringSubmatch(s)[1:])
+		return tc, FormUnpadded, err
+	case _reDotted.MatchString(s):
+		m := _reDotted.FindStringSubmatch(s)[1:]
+		tc, err := parseFields(fps, s, []string{m[0], m[1], m[2], ":", m[3]})
+		return tc, FormDotted, err
+	case _reCompact.MatchString(s):
+		tc, err := parseFields(fps, s, _reCompact.FindStringSubmatch(s)[1:])
+		return tc, FormCompact, err
+	case _reFrames.MatchString(s):
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, FormFrames, ErrInvalidTimeCode
+		}
+		tc, err := NewFromFrame(fps, n)
+		return tc, FormFrames, err
+	case _reSeconds.MatchString(s):
+		sec, err := strconv.ParseFloat(strings.TrimSuffix(s, "s"), 64)
+		if err != nil {
+			return nil, FormSeconds, ErrInvalidTimeCode
+		}
+		tc, err := New(fps, sec)
+		return tc, FormSeconds, err
+	case _reSRT.MatchString(s):
+		tc, err := NewFromSRT(fps, s)
+		return tc, FormMilliseconds, err
+	case _reWebVTT.MatchString(s):
+		tc, err := NewFromWebVTT(fps, s)
+		return tc, FormMilliseconds, err
+	default:
+		return nil, FormSMPTE, ErrInvalidTimeCode
+	}
+}
+
+// parseFields returns the timecode of the fields hours, minutes, seconds, separator and frames.
+func parseFields(fps float64, s string, fields []string) (*Timecode, error) {
+	const (
+		cHour = iota
+		cMin
+		cSec
+		cSep
+		cFrame
+		cMaxMinSec = 59
+	)
+	h1, m1, s1, f := atoi(fields[cHour]), atoi(fields[cMin]), atoi(fields[cSec]), atoi(fields[cFrame])
+	if m1 > cMaxMinSec || s1 > cMaxMinSec {
+		return nil, ErrInvalidTimeCode
+	}
+	tc := &Timecode{fps: fps}
+	switch fields[cSep] {
+	case ";":
+		tc.dropFrame = true
+	case ".":
+		tc.dropFrame = fps == FPS2997 && strings.Contains(s, ":")
+	}
+	if tc.dropFrame && fps != FPS2997 {
+		return nil, ErrInvalidFPS
+	}
+	if err := tc.setFields(h1, m1, s1, f); err != nil {
+		return nil, err
+	}
+	return tc, nil
+}
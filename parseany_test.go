@@ -0,0 +1,55 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"testing"
+)
+
+func TestParseAny(t *testing.T) {
+	require, assert := Describe(t)
+
+	tests := []struct {
+		s          string
+		fps        float64
+		expFrame   int
+		expDF      bool
+		expForm    Form
+		expSuccess bool
+	}{
+		{"01:02:03:04", cFPS25, 93079, false, FormSMPTE, true},
+		{" 01:02:03:04 ", cFPS25, 93079, false, FormSMPTE, true},
+		{"00:01:00;02", FPS2997, 1800, true, FormSMPTE, true},
+		{"00:01:00;02", cFPS25, 0, false, FormSMPTE, false},
+		{"1:02:03:04", cFPS25, 93079, false, FormUnpadded, true},
+		{"1:2:3:4", cFPS25, 93079, false, FormUnpadded, true},
+		{"01.02.03.04", cFPS25, 93079, false, FormDotted, true},
+		{"00.01.00.02", FPS2997, 1802, false, FormDotted, true},
+		{"00:01:00.02", FPS2997, 1800, true, FormUnpadded, true},
+		{"00:01:00.02", cFPS25, 1502, false, FormUnpadded, true},
+		{"00:01:00.00", FPS2997, 0, false, FormUnpadded, false},
+		{"010203:04", cFPS25, 93079, false, FormCompact, true},
+		{"000100;02", FPS2997, 1800, true, FormCompact, true},
+		{"86400", cFPS24, 86400, false, FormFrames, true},
+		{"123.45s", cFPS24, 2962, false, FormSeconds, true},
+		{"10s", cFPS24, 240, false, FormSeconds, true},
+		{"00:01:02,345", cFPS25, 1559, false, FormMilliseconds, true},
+		{"00:01:02.345", cFPS25, 1559, false, FormMilliseconds, true},
+		{"01:62:03:04", cFPS25, 0, false, FormUnpadded, false},
+		{"01:02:03:25", cFPS25, 0, false, FormSMPTE, false},
+		{"bad", cFPS25, 0, false, FormSMPTE, false},
+		{"86400", 0, 0, false, FormSMPTE, false},
+	}
+	for i, tt := range tests {
+		tc, form, err := ParseAny(tt.fps, tt.s)
+		require.Equal(tt.expSuccess, err == nil, "sample %d", i+1)
+		assert.Equal(tt.expForm, form, "sample %d", i+1)
+		if err == nil {
+			assert.Equal(tt.expFrame, tc.Frame(), "sample %d", i+1)
+			assert.Equal(tt.expDF, tc.Rate().DropFrame, "sample %d", i+1)
+		}
+	}
+	assert.Equal("compact", FormCompact.String())
+}
@@ -0,0 +1,110 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"fmt"
+)
+
+// Field is a field of a timecode string.
+type Field int
+
+const (
+	// FieldHours is the hours field.
+	FieldHours Field = iota
+	// FieldMinutes is the minutes field.
+	FieldMinutes
+	// FieldSeconds is the seconds field.
+	FieldSeconds
+	// FieldFrames is the frames field.
+	FieldFrames
+	// FieldSeparator is a separator between two fields.
+	FieldSeparator
+)
+
+// Reason is the reason of a parse error.
+type Reason int
+
+const (
+	// ReasonSyntax indicates an unexpected or a missing character.
+	ReasonSyntax Reason = iota
+	// ReasonOutOfRange indicates a field whose value is too large, e.g., 61 minutes or 25 frames at 25 FPS.
+	ReasonOutOfRange
+	// ReasonDroppedLabel indicates a label skipped by drop frame, e.g., 00:01:00;00.
+	ReasonDroppedLabel
+	// ReasonWrongSeparator indicates a separator before the frames that does not match the drop frame.
+	ReasonWrongSeparator
+)
+
+// ParseError describes the failure to parse a timecode string.  It matches ErrInvalidTimeCode with
+// errors.Is.  When the frames field is too large for the frame rate, it also matches ErrInconsistentFPS.
+type ParseError struct {
+	// Input is the parsed string.
+	Input string
+	// Field is the offending field.
+	Field Field
+	// Offset is the byte offset of the offending character or field in Input.
+	Offset int
+	Reason Reason
+	// Err is the sentinel error, i.e., ErrInvalidTimeCode or ErrInconsistentFPS.
+	Err error
+}
+
+// String returns the name of the field.
+func (f Field) String() string {
+	switch f {
+	case FieldHours:
+		return "hours"
+	case FieldMinutes:
+		return "minutes"
+	case FieldSeconds:
+		return "seconds"
+	case FieldFrames:
+		return "frames"
+	case FieldSeparator:
+		return "separator"
+	default:
+		return fmt.Sprintf("Field(%d)", int(f))
+	}
+}
+
+// String returns the description of the reason.
+func (r Reason) String() string {
+	switch r {
+	case ReasonSyntax:
+		return "syntax error"
+	case ReasonOutOfRange:
+		return "out of range"
+	case ReasonDroppedLabel:
+		return "label dropped in drop frame"
+	case ReasonWrongSeparator:
+		return "wrong separator for drop frame"
+	default:
+		return fmt.Sprintf("Reason(%d)", int(r))
+	}
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%v: %s at offset %d in %q: %s", e.Err, e.Field, e.Offset, e.Input, e.Reason)
+}
+
+// Unwrap returns the sentinel error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Is returns true if `target` is ErrInvalidTimeCode or the sentinel error.
+func (e *ParseError) Is(target error) bool {
+	return target == ErrInvalidTimeCode || target == e.Err
+}
+
+func newParseError(input string, field Field, offset int, reason Reason) *ParseError {
+	err := ErrInvalidTimeCode
+	if field == FieldFrames && reason == ReasonOutOfRange {
+		err = ErrInconsistentFPS
+	}
+	return &ParseError{Input: input, Field: field, Offset: offset, Reason: reason, Err: err}
+}
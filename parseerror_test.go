@@ -0,0 +1,55 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseError(t *testing.T) {
+	require, assert := Describe(t)
+
+	tests := []struct {
+		s         string
+		df        bool
+		expField  Field
+		expOffset int
+		expReason Reason
+		expFPS    bool
+	}{
+		{"a2:34:56:22", false, FieldHours, 0, ReasonSyntax, false},
+		{"12:3a:56:22", false, FieldMinutes, 4, ReasonSyntax, false},
+		{"12-34:56:22", false, FieldSeparator, 2, ReasonSyntax, false},
+		{"12:34:56", false, FieldSeparator, 8, ReasonSyntax, false},
+		{"12:34:56:2", false, FieldFrames, 10, ReasonSyntax, false},
+		{"12:34:56:222", false, FieldFrames, 11, ReasonSyntax, false},
+		{"12:34;56:22", false, FieldSeparator, 5, ReasonSyntax, false},
+		{"12:64:56:22", false, FieldMinutes, 3, ReasonOutOfRange, false},
+		{"12:34:66:22", false, FieldSeconds, 6, ReasonOutOfRange, false},
+		{"12:34:56:30", false, FieldFrames, 9, ReasonOutOfRange, true},
+		{"12:34:56:22", true, FieldSeparator, 8, ReasonWrongSeparator, false},
+		{"12:34:00;01", true, FieldFrames, 9, ReasonDroppedLabel, false},
+	}
+	for i, tt := range tests {
+		tc, _ := NewFromFrame(FPS2997, 0)
+		if tt.df {
+			tc, _ = NewWithDropFrame(0)
+		}
+		err := tc.Parse(tt.s)
+		require.Error(err, "sample %d", i+1)
+		var pe *ParseError
+		require.True(errors.As(err, &pe), "sample %d", i+1)
+		assert.Equal(tt.s, pe.Input, "sample %d", i+1)
+		assert.Equal(tt.expField, pe.Field, "sample %d", i+1)
+		assert.Equal(tt.expOffset, pe.Offset, "sample %d", i+1)
+		assert.Equal(tt.expReason, pe.Reason, "sample %d", i+1)
+		assert.ErrorIs(err, ErrInvalidTimeCode, "sample %d", i+1)
+		assert.Equal(tt.expFPS, errors.Is(err, ErrInconsistentFPS), "sample %d", i+1)
+	}
+	tc, _ := NewWithDropFrame(0)
+	assert.EqualError(tc.Parse("00:01:00;00"),
+		`invalid timecode: frames at offset 9 in "00:01:00;00": label dropped in drop frame`)
+}
@@ -0,0 +1,338 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+// Package qt reads and rewrites the start timecode of the `tmcd` track of QuickTime and MP4 files.  It
+// walks the ISO base media file format box tree to the `tmcd` sample entry and its first sample, i.e., the
+// 32-bit frame number of the start timecode.
+package qt
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/wunderbarb/timecode"
+)
+
+var (
+	// ErrInvalidBox is returned when a box is truncated or malformed.
+	ErrInvalidBox = errors.New("qt: invalid box")
+	// ErrNoTimecode is returned when the file has no tmcd track.
+	ErrNoTimecode = errors.New("qt: no timecode track")
+	// ErrRateMismatch is returned when the timecode to write does not have the rate of the track.
+	ErrRateMismatch = errors.New("qt: rate mismatch")
+)
+
+// The flags of the tmcd sample entry.
+const (
+	// FlagDropFrame indicates a drop frame timecode.
+	FlagDropFrame uint32 = 0x1
+	// Flag24HourMax indicates that the timecode wraps at 24 hours.
+	Flag24HourMax uint32 = 0x2
+	// FlagNegativeTimesOK indicates that the timecode may be negative.
+	FlagNegativeTimesOK uint32 = 0x4
+	// FlagCounter indicates a counter instead of a timecode.
+	FlagCounter uint32 = 0x8
+)
+
+const (
+	cTmcd          = "tmcd"
+	cHeaderSize    = 8
+	cLargeSize     = 8
+	cFullBoxHeader = 4
+	cEntryHeader   = 8
+	// The offsets of the fields of the tmcd sample entry after its header.
+	cOffsetFlags          = 12
+	cOffsetTimescale      = 16
+	cOffsetFrameDuration  = 20
+	cOffsetNumberOfFrames = 24
+	cTmcdSize             = 26
+	cSampleSize           = 4
+	cCountSize            = 4
+	cOffsetEntryType      = cFullBoxHeader + cCountSize + 4
+)
+
+// Track is the tmcd track of a file.
+type Track struct {
+	// Flags are the flags of the tmcd sample entry, e.g., FlagDropFrame.
+	Flags uint32
+	// Timescale is the number of time units per second.
+	Timescale uint32
+	// FrameDuration is the duration of a frame in time units.
+	FrameDuration uint32
+	// NumberOfFrames is the number of frames per second of the timecode, e.g., 30 at 29.97 FPS.
+	NumberOfFrames uint8
+	// Start is the start timecode.  A negative start is counted back from midnight.
+	Start timecode.Timecode
+	// SampleOffset is the offset in the file of the 32-bit frame number of the start timecode.
+	SampleOffset int64
+}
+
+// box is a box of the file.  Its body spans from `start` to `end`.
+type box struct {
+	typ   string
+	start int64
+	end   int64
+}
+
+// ReadFile returns the tmcd track of the file `name`.
+func ReadFile(name string) (*Track, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Read returns the first tmcd track of the file read from `r`.
+func Read(r io.ReadSeeker) (*Track, error) {
+	end, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+	moov, err := path(r, box{start: 0, end: end}, "moov")
+	if err != nil {
+		return nil, err
+	}
+	boxes, err := readBoxes(r, moov)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range boxes {
+		if b.typ != "trak" {
+			continue
+		}
+		t, err := readTrack(r, b)
+		if errors.Is(err, ErrNoTimecode) {
+			continue
+		}
+		return t, err
+	}
+	return nil, ErrNoTimecode
+}
+
+// Rate returns the timecode rate of the track.  A rate close to an NTSC rate, e.g., 2997/100, is snapped
+// to it as by timecode.SnapNTSC.
+func (t *Track) Rate() (timecode.Rate, error) {
+	if t.Timescale == 0 || t.FrameDuration == 0 {
+		return timecode.Rate{}, errors.Wrapf(timecode.ErrInvalidFPS, "%d/%d", t.Timescale, t.FrameDuration)
+	}
+	fps := timecode.SnapNTSC(float64(t.Timescale) / float64(t.FrameDuration))
+	r := timecode.Rate{FPS: fps, DropFrame: t.Flags&FlagDropFrame != 0}
+	if !r.Valid() {
+		return timecode.Rate{}, errors.Wrapf(timecode.ErrInvalidFPS, "%d/%d", t.Timescale, t.FrameDuration)
+	}
+	return r, nil
+}
+
+// WriteFile rewrites in place the start timecode of the tmcd track of the file `name` to `tc`.
+func WriteFile(name string, tc timecode.Timecode) error {
+	f, err := os.OpenFile(name, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	t, err := Read(f)
+	if err == nil {
+		err = t.WriteStart(f, tc)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// WriteStart writes the start timecode `tc` to the sample of the track in `w`, i.e., the file the track
+// was read from, and updates the Start of the track.  The rate of `tc` must be the rate of the track.
+func (t *Track) WriteStart(w io.WriterAt, tc timecode.Timecode) error {
+	r, err := t.Rate()
+	if err != nil {
+		return err
+	}
+	if tc.Rate() != r {
+		return errors.Wrapf(ErrRateMismatch, "%v for a track at %v", tc.Rate(), r)
+	}
+	frame, limit := int64(tc.Frame()), int64(math.MaxUint32)
+	if t.Flags&FlagNegativeTimesOK != 0 {
+		limit = math.MaxInt32
+	}
+	if frame > limit {
+		return errors.Wrapf(timecode.ErrInvalidTimeCode, "frame %d", frame)
+	}
+	var b [cSampleSize]byte
+	binary.BigEndian.PutUint32(b[:], uint32(frame))
+	if _, err := w.WriteAt(b[:], t.SampleOffset); err != nil {
+		return err
+	}
+	t.Start = tc
+	return nil
+}
+
+// readTrack returns the tmcd track of the `trak` box or ErrNoTimecode.
+func readTrack(r io.ReadSeeker, trak box) (*Track, error) {
+	stbl, err := path(r, trak, "mdia", "minf", "stbl")
+	if err != nil {
+		return nil, err
+	}
+	boxes, err := readBoxes(r, stbl)
+	if err != nil {
+		return nil, err
+	}
+	stsd, err := readBody(r, find(boxes, "stsd"))
+	if err != nil {
+		return nil, err
+	}
+	if len(stsd) < cFullBoxHeader+cCountSize+cEntryHeader ||
+		string(stsd[cOffsetEntryType:cFullBoxHeader+cCountSize+cEntryHeader]) != cTmcd {
+		return nil, ErrNoTimecode
+	}
+	entry := stsd[cFullBoxHeader+cCountSize+cEntryHeader:]
+	if len(entry) < cTmcdSize {
+		return nil, errors.Wrapf(ErrInvalidBox, "tmcd of %d bytes", len(entry))
+	}
+	t := &Track{
+		Flags:          binary.BigEndian.Uint32(entry[cOffsetFlags:]),
+		Timescale:      binary.BigEndian.Uint32(entry[cOffsetTimescale:]),
+		FrameDuration:  binary.BigEndian.Uint32(entry[cOffsetFrameDuration:]),
+		NumberOfFrames: entry[cOffsetNumberOfFrames],
+	}
+	if t.SampleOffset, err = firstChunkOffset(r, boxes); err != nil {
+		return nil, err
+	}
+	rate, err := t.Rate()
+	if err != nil {
+		return nil, err
+	}
+	var b [cSampleSize]byte
+	if _, err := r.Seek(t.SampleOffset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return nil, errors.Wrapf(ErrInvalidBox, "tmcd sample at %d: %v", t.SampleOffset, err)
+	}
+	frame := int(binary.BigEndian.Uint32(b[:]))
+	if t.Flags&FlagNegativeTimesOK != 0 {
+		frame = int(int32(binary.BigEndian.Uint32(b[:])))
+	}
+	start, err := newStart(rate, frame)
+	if err != nil {
+		return nil, err
+	}
+	t.Start = *start
+	return t, nil
+}
+
+// newStart returns the timecode of the frame `frame`.  A negative frame is counted back from the
+// 00:00:00:00 label, e.g., -1 is 23:59:59:29 at 30 FPS.
+func newStart(r timecode.Rate, frame int) (*timecode.Timecode, error) {
+	if frame >= 0 {
+		return timecode.NewFromRate(r, frame)
+	}
+	tc, err := timecode.NewFromRate(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	sep := ":"
+	if r.DropFrame {
+		sep = ";"
+	}
+	if err := tc.Parse(fmt.Sprintf("23:59:59%s%02d", sep, int(math.Round(r.FPS))-1)); err != nil {
+		return nil, err
+	}
+	tc.SetFrame(tc.Frame() + 1 + frame)
+	return tc, nil
+}
+
+// firstChunkOffset returns the offset of the first chunk of the `stco` or `co64` box of `boxes`.
+func firstChunkOffset(r io.ReadSeeker, boxes []box) (int64, error) {
+	if b := find(boxes, "co64"); b.typ != "" {
+		body, err := readBody(r, b)
+		if err != nil || len(body) < cFullBoxHeader+cCountSize+cLargeSize {
+			return 0, errors.Wrapf(ErrInvalidBox, "co64 of %d bytes", len(body))
+		}
+		return int64(binary.BigEndian.Uint64(body[cFullBoxHeader+cCountSize:])), nil
+	}
+	body, err := readBody(r, find(boxes, "stco"))
+	if err != nil || len(body) < cFullBoxHeader+cCountSize+cSampleSize {
+		return 0, errors.Wrapf(ErrInvalidBox, "stco of %d bytes", len(body))
+	}
+	return int64(binary.BigEndian.Uint32(body[cFullBoxHeader+cCountSize:])), nil
+}
+
+// path returns the box at the path `types` inside the box `parent`.
+func path(r io.ReadSeeker, parent box, types ...string) (box, error) {
+	b := parent
+	for _, typ := range types {
+		boxes, err := readBoxes(r, b)
+		if err != nil {
+			return box{}, err
+		}
+		if b = find(boxes, typ); b.typ == "" {
+			return box{}, errors.Wrapf(ErrInvalidBox, "no %q in %q", typ, parent.typ)
+		}
+	}
+	return b, nil
+}
+
+// readBoxes returns the boxes inside the box `parent`.
+func readBoxes(r io.ReadSeeker, parent box) ([]box, error) {
+	var boxes []box
+	for pos := parent.start; pos < parent.end; {
+		if _, err := r.Seek(pos, io.SeekStart); err != nil {
+			return nil, err
+		}
+		var hdr [cHeaderSize + cLargeSize]byte
+		if _, err := io.ReadFull(r, hdr[:cHeaderSize]); err != nil {
+			return nil, errors.Wrapf(ErrInvalidBox, "header at %d: %v", pos, err)
+		}
+		size := int64(binary.BigEndian.Uint32(hdr[:]))
+		b := box{typ: string(hdr[4:cHeaderSize]), start: pos + cHeaderSize}
+		switch size {
+		case 0:
+			size = parent.end - pos
+		case 1:
+			if _, err := io.ReadFull(r, hdr[cHeaderSize:]); err != nil {
+				return nil, errors.Wrapf(ErrInvalidBox, "%q large size at %d: %v", b.typ, pos, err)
+			}
+			size = int64(binary.BigEndian.Uint64(hdr[cHeaderSize:]))
+			b.start += cLargeSize
+		}
+		b.end = pos + size
+		if b.end < b.start || b.end > parent.end {
+			return nil, errors.Wrapf(ErrInvalidBox, "%q of %d bytes at %d", b.typ, size, pos)
+		}
+		boxes = append(boxes, b)
+		pos = b.end
+	}
+	return boxes, nil
+}
+
+// readBody returns the body of the box `b`.
+func readBody(r io.ReadSeeker, b box) ([]byte, error) {
+	if b.typ == "" {
+		return nil, nil
+	}
+	if _, err := r.Seek(b.start, io.SeekStart); err != nil {
+		return nil, err
+	}
+	body := make([]byte, b.end-b.start)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, errors.Wrapf(ErrInvalidBox, "%q: %v", b.typ, err)
+	}
+	return body, nil
+}
+
+// find returns the first box of type `typ` of `boxes` or the zero box.
+func find(boxes []box, typ string) box {
+	for _, b := range boxes {
+		if b.typ == typ {
+			return b
+		}
+	}
+	return box{}
+}
@@ -0,0 +1,172 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package qt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wunderbarb/timecode"
+)
+
+var testCounter int
+
+func TestReadFile(t *testing.T) {
+	_, assert := Describe(t)
+
+	tests := []struct {
+		name      string
+		want      string
+		wantRate  timecode.Rate
+		wantFlags uint32
+	}{
+		{"testdata/df2997.mov", "01:00:00;00", timecode.Rate2997DF, FlagDropFrame | Flag24HourMax},
+		{"testdata/pal25.mp4", "10:00:00:00", timecode.Rate25, Flag24HourMax},
+	}
+	for i, tt := range tests {
+		tr, err := ReadFile(tt.name)
+		assert.NoError(err, "sample %d", i+1)
+		if err != nil {
+			continue
+		}
+		assert.Equal(tt.want, tr.Start.String(), "sample %d", i+1)
+		assert.Equal(tt.wantRate, tr.Start.Rate(), "sample %d", i+1)
+		assert.Equal(tt.wantFlags, tr.Flags, "sample %d", i+1)
+	}
+	_, err := ReadFile("testdata/missing.mov")
+	assert.Error(err)
+}
+
+func TestRead(t *testing.T) {
+	require, assert := Describe(t)
+
+	data, err := os.ReadFile("testdata/df2997.mov")
+	require.NoError(err)
+	tr, err := Read(bytes.NewReader(data))
+	require.NoError(err)
+	assert.Equal(uint32(30000), tr.Timescale)
+	assert.Equal(uint32(1001), tr.FrameDuration)
+	assert.Equal(uint8(30), tr.NumberOfFrames)
+	assert.Equal(uint32(107892), binary.BigEndian.Uint32(data[tr.SampleOffset:]))
+
+	// Negative times.
+	neg := bytes.Clone(data)
+	i := bytes.Index(neg, []byte("tmcd\x00\x00\x00\x00\x00\x00\x00\x01")) + cEntryHeader - cSampleSize + cOffsetFlags
+	binary.BigEndian.PutUint32(neg[i:], FlagDropFrame|FlagNegativeTimesOK)
+	binary.BigEndian.PutUint32(neg[tr.SampleOffset:], uint32(0xFFFFFFFE))
+	tn, err := Read(bytes.NewReader(neg))
+	require.NoError(err)
+	assert.Equal("23:59:59;28", tn.Start.String())
+
+	_, err = Read(bytes.NewReader(data[:len(data)-1]))
+	assert.ErrorIs(err, ErrInvalidBox)
+	_, err = Read(strings.NewReader("\x00\x00\x00\x08free"))
+	assert.ErrorIs(err, ErrInvalidBox)
+	noTmcd := bytes.ReplaceAll(data, []byte("tmcd"), []byte("text"))
+	_, err = Read(bytes.NewReader(noTmcd))
+	assert.ErrorIs(err, ErrNoTimecode)
+}
+
+func TestTrack_Rate(t *testing.T) {
+	_, assert := Describe(t)
+
+	tests := []struct {
+		track   Track
+		want    timecode.Rate
+		wantErr bool
+	}{
+		{Track{Timescale: 30000, FrameDuration: 1001, NumberOfFrames: 30, Flags: FlagDropFrame},
+			timecode.Rate2997DF, false},
+		{Track{Timescale: 2997, FrameDuration: 100, NumberOfFrames: 30}, timecode.Rate2997, false},
+		{Track{Timescale: 24000, FrameDuration: 1001, NumberOfFrames: 24}, timecode.Rate23976, false},
+		{Track{Timescale: 5994, FrameDuration: 100, NumberOfFrames: 60}, timecode.Rate{FPS: timecode.NTSCRate(60)},
+			false},
+		{Track{Timescale: 600, FrameDuration: 24, NumberOfFrames: 25}, timecode.Rate25, false},
+		{Track{Timescale: 600, FrameDuration: 0, NumberOfFrames: 25}, timecode.Rate{}, true},
+		{Track{Timescale: 25, FrameDuration: 1, NumberOfFrames: 25, Flags: FlagDropFrame}, timecode.Rate{}, true},
+	}
+	for i, tt := range tests {
+		r, err := tt.track.Rate()
+		assert.Equal(tt.wantErr, err != nil, "sample %d", i+1)
+		assert.Equal(tt.want, r, "sample %d", i+1)
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	require, assert := Describe(t)
+
+	for i, name := range []string{"df2997.mov", "pal25.mp4"} {
+		data, err := os.ReadFile(filepath.Join("testdata", name))
+		require.NoError(err)
+		p := filepath.Join(t.TempDir(), name)
+		require.NoError(os.WriteFile(p, data, 0o600))
+		tr, err := ReadFile(p)
+		require.NoError(err)
+		tc, _ := timecode.NewFromRate(tr.Start.Rate(), 0)
+		require.NoError(tc.Parse(strings.Replace(tr.Start.String(), "00:00", "23:10", 1)))
+		require.NoError(WriteFile(p, *tc), "sample %d", i+1)
+		tr1, err := ReadFile(p)
+		require.NoError(err)
+		assert.True(tr1.Start.Equal(*tc), "sample %d", i+1)
+		out, _ := os.ReadFile(p)
+		assert.Equal(len(data), len(out), "sample %d", i+1)
+	}
+	tc, _ := timecode.NewFromRate(timecode.Rate24, 0)
+	assert.ErrorIs(WriteFile("testdata/df2997.mov", *tc), ErrRateMismatch)
+}
+
+func TestTrack_WriteStart(t *testing.T) {
+	require, assert := Describe(t)
+
+	data, err := os.ReadFile("testdata/pal25.mp4")
+	require.NoError(err)
+	tr, err := Read(bytes.NewReader(data))
+	require.NoError(err)
+	w := &writerAt{b: data}
+	tc, _ := timecode.NewFromRate(timecode.Rate25, 1234)
+	require.NoError(tr.WriteStart(w, *tc))
+	assert.Equal(1234, tr.Start.Frame())
+	assert.Equal(uint32(1234), binary.BigEndian.Uint32(data[tr.SampleOffset:]))
+	tc.SetFrame(math.MaxInt32)
+	tc.Offset(1)
+	if tc.Frame() < 0 {
+		// A 32-bit int cannot hold frames beyond the limit.
+		return
+	}
+	require.NoError(tr.WriteStart(w, *tc))
+	tr.Flags |= FlagNegativeTimesOK
+	assert.ErrorIs(tr.WriteStart(w, *tc), timecode.ErrInvalidTimeCode)
+}
+
+// writerAt is an io.WriterAt over a byte slice.
+type writerAt struct {
+	b []byte
+}
+
+func (w *writerAt) WriteAt(p []byte, off int64) (int, error) {
+	return copy(w.b[off:], p), nil
+}
+
+func Describe(t *testing.T, msg ...string) (*require.Assertions,
+	*assert.Assertions) {
+
+	dispMsg := ""
+	if len(msg) != 0 {
+		dispMsg = msg[0]
+	}
+	name := strings.TrimPrefix(strings.TrimPrefix(t.Name(), "Test"), "_")
+	fmt.Printf("Test %d: %s %s\n", testCounter, name, dispMsg)
+	testCounter++
+	return require.New(t), assert.New(t)
+}
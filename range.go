@@ -0,0 +1,50 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidRange is returned when the end of a range or of bounds is before its start.
+var ErrInvalidRange = errors.New("invalid range")
+
+// Range is a span of `Duration` frames starting at the timecode `Start`.
+type Range struct {
+	Start    Timecode
+	Duration int
+}
+
+// NewRange returns the range from `start` to `end` excluded.  They must have the same frame rate and
+// drop frame, and `end` must not be before `start`.
+func NewRange(start Timecode, end Timecode) (Range, error) {
+	if !start.sameFrameRate(end) {
+		return Range{}, ErrInconsistentFPS
+	}
+	if end.currentFrame < start.currentFrame {
+		return Range{}, errors.Wrapf(ErrInvalidRange, "end %v before start %v", end, start)
+	}
+	return Range{Start: start, Duration: end.currentFrame - start.currentFrame}, nil
+}
+
+// End returns the timecode of the first frame after the range.
+func (r Range) End() Timecode {
+	end := r.Start
+	end.currentFrame += r.Duration
+	return end
+}
+
+// Contains returns true if the timecode `tc` is in the range.  It must have the frame rate and drop
+// frame of the range.
+func (r Range) Contains(tc Timecode) bool {
+	return r.Start.sameFrameRate(tc) && tc.currentFrame >= r.Start.currentFrame &&
+		tc.currentFrame < r.Start.currentFrame+r.Duration
+}
+
+// String returns the range as HH:MM:SS:ff-HH:MM:SS:ff with the end excluded.
+func (r Range) String() string {
+	end := r.End()
+	return r.Start.String() + "-" + end.String()
+}
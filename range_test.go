@@ -0,0 +1,31 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"testing"
+)
+
+func TestNewRange(t *testing.T) {
+	require, assert := Describe(t)
+
+	start, _ := NewFromString(cFPS25, "01:00:00:00")
+	end, _ := NewFromString(cFPS25, "01:00:01:00")
+	r, err := NewRange(*start, *end)
+	require.NoError(err)
+	assert.Equal(25, r.Duration)
+	assert.True(r.Contains(*start))
+	assert.False(r.Contains(*end))
+	e := r.End()
+	assert.True(e.Equal(*end))
+	assert.Equal("01:00:00:00-01:00:01:00", r.String())
+
+	_, err = NewRange(*end, *start)
+	assert.ErrorIs(err, ErrInvalidRange)
+	other, _ := NewFromString(cFPS24, "01:00:01:00")
+	_, err = NewRange(*start, *other)
+	assert.ErrorIs(err, ErrInconsistentFPS)
+	assert.False(r.Contains(*other))
+}
@@ -0,0 +1,94 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"math"
+	"strconv"
+)
+
+const (
+	// cNTSCDen is the denominator of the NTSC frame rates, e.g., 30000/1001.
+	cNTSCDen = 1001
+	// cNTSCTolerance is the largest distance of a frame rate to the NTSC rate it snaps to.
+	cNTSCTolerance = 1e-3
+)
+
+// Rate is a frame rate with its drop frame mode.  Drop frame is only supported at 29.97 FPS.
+type Rate struct {
+	FPS       float64
+	DropFrame bool
+}
+
+var (
+	// Rate23976 is 23.976 FPS.
+	Rate23976 = Rate{FPS: FPS23976fps}
+	// Rate24 is 24 FPS.
+	Rate24 = Rate{FPS: 24}
+	// Rate25 is 25 FPS.
+	Rate25 = Rate{FPS: 25}
+	// Rate2997 is 29.97 FPS without drop frame.
+	Rate2997 = Rate{FPS: FPS2997}
+	// Rate2997DF is 29.97 FPS with drop frame.
+	Rate2997DF = Rate{FPS: FPS2997, DropFrame: true}
+	// Rate30 is 30 FPS.
+	Rate30 = Rate{FPS: 30}
+)
+
+// NewFromRate initializes a Timecode structure with the given rate and frame.  The first frame is 0.
+func NewFromRate(r Rate, frame int) (*Timecode, error) {
+	if !r.Valid() {
+		return nil, ErrInvalidFPS
+	}
+	tc, err := NewFromFrame(r.FPS, frame)
+	if err != nil {
+		return nil, err
+	}
+	tc.dropFrame = r.DropFrame
+	return tc, nil
+}
+
+// Rate returns the frame rate and drop frame of the timecode.
+func (t *Timecode) Rate() Rate {
+	return Rate{FPS: t.fps, DropFrame: t.dropFrame}
+}
+
+// Valid returns true if the frame rate is positive and drop frame, if any, is at 29.97 FPS.
+func (r Rate) Valid() bool {
+	return r.FPS > 0.0 && (!r.DropFrame || r.FPS == FPS2997)
+}
+
+// String returns the frame rate with at most three decimals followed by `DF` if drop frame,
+// e.g., `29.97DF`, `23.976` or `25`.
+func (r Rate) String() string {
+	s := strconv.FormatFloat(float64(cast2Round(r.FPS*cPrecision))/cPrecision, 'f', -1, 64)
+	if r.DropFrame {
+		s += "DF"
+	}
+	return s
+}
+
+// Rational returns the frame rate as the fraction `num`/`den`.  The denominator is 1 for an integer rate,
+// 1001 for an NTSC rate, e.g., 30000/1001 at 29.97 FPS, 1000 for a rate with at most three decimals and
+// else 1000000.
+func (r Rate) Rational() (num int64, den int64) {
+	n, d := ratio(r.FPS)
+	return int64(n), int64(d)
+}
+
+// NTSCRate returns the NTSC frame rate of the nominal frame rate `n`, i.e., n*1000/1001, e.g.,
+// FPS2997 for 30.
+func NTSCRate(n float64) float64 {
+	return n * cPrecision / cNTSCDen
+}
+
+// SnapNTSC returns the NTSC frame rate n*1000/1001 that `fps` approximates, e.g., FPS2997 for 29.97,
+// or else `fps`.
+func SnapNTSC(fps float64) float64 {
+	if ntsc := NTSCRate(math.Round(fps * cNTSCDen / cPrecision)); math.Abs(fps-ntsc) < cNTSCTolerance {
+		return ntsc
+	}
+	return fps
+}
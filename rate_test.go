@@ -0,0 +1,52 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"testing"
+)
+
+func TestRate_Rational(t *testing.T) {
+	_, assert := Describe(t)
+
+	tests := []struct {
+		r      Rate
+		expNum int64
+		expDen int64
+	}{
+		{Rate25, 25, 1},
+		{Rate2997DF, 30000, 1001},
+		{Rate23976, 24000, 1001},
+		{Rate{FPS: 12.5}, 12500, 1000},
+		{Rate{FPS: 29.97}, 29970, 1000},
+		{Rate{FPS: SnapNTSC(29.97)}, 30000, 1001},
+	}
+	for i, tt := range tests {
+		num, den := tt.r.Rational()
+		assert.Equal(tt.expNum, num, "sample %d", i+1)
+		assert.Equal(tt.expDen, den, "sample %d", i+1)
+	}
+}
+
+func TestSnapNTSC(t *testing.T) {
+	_, assert := Describe(t)
+
+	tests := []struct {
+		fps    float64
+		expRes float64
+	}{
+		{29.97, FPS2997},
+		{23.976, FPS23976fps},
+		{float64(float32(59.94)), NTSCRate(60)},
+		{30, 30},
+		{25, 25},
+		{23.98, 23.98},
+		{12.5, 12.5},
+	}
+	for i, tt := range tests {
+		assert.Equal(tt.expRes, SnapNTSC(tt.fps), "sample %d", i+1)
+	}
+	assert.Equal(FPS2997, NTSCRate(30))
+}
@@ -0,0 +1,45 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"math"
+	"math/bits"
+)
+
+// NewFromSamples initializes a Timecode structure with the rate `r` at the frame that contains the
+// audio sample `samples` at the sample rate `sampleRate`, e.g., the Broadcast WAV TimeReference.
+func NewFromSamples(r Rate, samples int64, sampleRate int) (*Timecode, error) {
+	if !r.Valid() || samples < 0 || sampleRate <= 0 {
+		return nil, ErrInvalidFPS
+	}
+	num, den := ratio(r.FPS)
+	frame, ok := mulDiv(uint64(samples), num, uint64(sampleRate)*den)
+	if !ok || frame > math.MaxInt {
+		return nil, ErrInvalidFPS
+	}
+	return &Timecode{fps: r.FPS, dropFrame: r.DropFrame, currentFrame: int(frame)}, nil
+}
+
+// Samples returns the first audio sample of the frame at the sample rate `sampleRate`.  It is the
+// smallest sample that NewFromSamples maps to the frame.  It saturates at math.MaxInt64.
+func (t *Timecode) Samples(sampleRate int) int64 {
+	if t.currentFrame < 0 {
+		return -(&Timecode{fps: t.fps, currentFrame: -t.currentFrame}).Samples(sampleRate)
+	}
+	num, den := ratio(t.fps)
+	hi, lo := bits.Mul64(uint64(t.currentFrame), uint64(sampleRate)*den)
+	if hi >= num {
+		return math.MaxInt64
+	}
+	q, rem := bits.Div64(hi, lo, num)
+	if rem != 0 {
+		q++
+	}
+	if q > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(q)
+}
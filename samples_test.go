@@ -0,0 +1,55 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewFromSamples(t *testing.T) {
+	require, assert := Describe(t)
+
+	tests := []struct {
+		r          Rate
+		samples    int64
+		sampleRate int
+		exp        string
+		expSuccess bool
+	}{
+		{Rate25, 172800000, 48000, "01:00:00:00", true},
+		{Rate25, 172800000 - 1, 48000, "00:59:59:24", true},
+		{Rate2997DF, 172799828, 48000, "01:00:00;00", true},
+		{Rate2997DF, 172799827, 48000, "00:59:59;29", true},
+		{Rate23976, 172972800, 48000, "01:00:00:00", true},
+		{Rate24, 0, 96000, "00:00:00:00", true},
+		{Rate24, -1, 48000, "", false},
+		{Rate24, 1, 0, "", false},
+		{Rate{FPS: cFPS25, DropFrame: true}, 1, 48000, "", false},
+		{Rate{FPS: 1e6}, math.MaxInt64, 1, "", false},
+	}
+	for i, tt := range tests {
+		tc, err := NewFromSamples(tt.r, tt.samples, tt.sampleRate)
+		require.Equal(tt.expSuccess, err == nil, "sample %d", i+1)
+		if err == nil {
+			assert.Equal(tt.exp, tc.String(), "sample %d", i+1)
+		}
+	}
+}
+
+func TestTimecode_Samples(t *testing.T) {
+	_, assert := Describe(t)
+
+	tc, _ := NewFromRate(Rate2997DF, 1)
+	assert.Equal(int64(1602), tc.Samples(48000))
+	tc, _ = NewFromRate(Rate2997DF, 5)
+	assert.Equal(int64(8008), tc.Samples(48000))
+	tc, _ = NewFromRate(Rate25, 90000)
+	assert.Equal(int64(172800000), tc.Samples(48000))
+	tc.Offset(-90001)
+	assert.Equal(int64(-1920), tc.Samples(48000))
+	tc, _ = NewFromRate(Rate{FPS: 1e-6}, math.MaxInt32)
+	assert.Equal(int64(math.MaxInt64), tc.Samples(math.MaxInt32))
+}
@@ -0,0 +1,148 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package sei
+
+import (
+	"github.com/pkg/errors"
+)
+
+const (
+	cMaxExpGolombZeros = 31
+	cEmulation         = 0x03
+)
+
+// bitReader reads the bits of a raw byte sequence payload, most significant bit first.
+type bitReader struct {
+	b   []byte
+	pos int
+	err error
+}
+
+// u reads an unsigned integer of `n` bits, with n <= 32.
+func (r *bitReader) u(n int) uint32 {
+	var v uint32
+	for i := 0; i < n; i++ {
+		if r.pos >= 8*len(r.b) {
+			if r.err == nil {
+				r.err = errors.Wrapf(ErrInvalidPayload, "%d bits read from %d bytes", r.pos+n-i, len(r.b))
+			}
+			return 0
+		}
+		v = v<<1 | uint32(r.b[r.pos/8]>>(7-r.pos%8)&1)
+		r.pos++
+	}
+	return v
+}
+
+// flag reads a bit as a boolean.
+func (r *bitReader) flag() bool {
+	return r.u(1) == 1
+}
+
+// ue reads an unsigned Exp-Golomb integer.
+func (r *bitReader) ue() uint32 {
+	zeros := 0
+	for r.u(1) == 0 && r.err == nil {
+		zeros++
+		if zeros > cMaxExpGolombZeros {
+			r.err = errors.Wrapf(ErrInvalidPayload, "Exp-Golomb code of %d leading zeros", zeros)
+			return 0
+		}
+	}
+	return 1<<zeros - 1 + r.u(zeros)
+}
+
+// se reads a signed Exp-Golomb integer.
+func (r *bitReader) se() int32 {
+	v := r.ue()
+	if v%2 == 1 {
+		return int32(v/2 + 1)
+	}
+	return -int32(v / 2)
+}
+
+// i reads a two's complement signed integer of `n` bits.
+func (r *bitReader) i(n int) int32 {
+	v := r.u(n)
+	if n > 0 && n < 32 && v>>(n-1) == 1 {
+		return int32(v) - 1<<n
+	}
+	return int32(v)
+}
+
+// bitWriter writes bits, most significant bit first.
+type bitWriter struct {
+	b []byte
+	n int
+}
+
+// u writes the `n` least significant bits of `v`.
+func (w *bitWriter) u(n int, v uint32) {
+	for i := n - 1; i >= 0; i-- {
+		if w.n%8 == 0 {
+			w.b = append(w.b, 0)
+		}
+		w.b[len(w.b)-1] |= byte(v>>i&1) << (7 - w.n%8)
+		w.n++
+	}
+}
+
+// flag writes a boolean as a bit.
+func (w *bitWriter) flag(f bool) {
+	if f {
+		w.u(1, 1)
+		return
+	}
+	w.u(1, 0)
+}
+
+// align writes a one bit followed by zero bits up to the next byte boundary if the writer is not byte
+// aligned, as the payload of an SEI message ends.
+func (w *bitWriter) align() {
+	if w.n%8 != 0 {
+		w.u(1, 1)
+		w.n += (8 - w.n%8) % 8
+	}
+}
+
+// unescape returns the raw byte sequence payload of the NAL unit payload `b`, i.e., without the emulation
+// prevention bytes.
+func unescape(b []byte) []byte {
+	out := make([]byte, 0, len(b))
+	zeros := 0
+	for _, c := range b {
+		if zeros >= 2 && c == cEmulation {
+			zeros = 0
+			continue
+		}
+		out = append(out, c)
+		if c == 0 {
+			zeros++
+		} else {
+			zeros = 0
+		}
+	}
+	return out
+}
+
+// escape returns the NAL unit payload of the raw byte sequence payload `b`, i.e., with the emulation
+// prevention bytes that avoid start codes.
+func escape(b []byte) []byte {
+	out := make([]byte, 0, len(b)+len(b)/2)
+	zeros := 0
+	for _, c := range b {
+		if zeros >= 2 && c <= cEmulation {
+			out = append(out, cEmulation)
+			zeros = 0
+		}
+		out = append(out, c)
+		if c == 0 {
+			zeros++
+		} else {
+			zeros = 0
+		}
+	}
+	return out
+}
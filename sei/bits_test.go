@@ -0,0 +1,78 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package sei
+
+import (
+	"math/bits"
+	"testing"
+)
+
+func TestBitReader(t *testing.T) {
+	require, assert := Describe(t)
+
+	w := &bitWriter{}
+	w.u(3, 5)
+	writeUE(w, 0)
+	writeUE(w, 7)
+	writeSE(w, -3)
+	writeSE(w, 2)
+	w.u(5, 0x1E)
+	w.flag(true)
+	w.align()
+	r := &bitReader{b: w.b}
+	assert.Equal(uint32(5), r.u(3))
+	assert.Equal(uint32(0), r.ue())
+	assert.Equal(uint32(7), r.ue())
+	assert.Equal(int32(-3), r.se())
+	assert.Equal(int32(2), r.se())
+	assert.Equal(int32(-2), r.i(5))
+	assert.True(r.flag())
+	require.NoError(r.err)
+	assert.True(r.flag())
+	for r.pos%8 != 0 {
+		assert.False(r.flag())
+	}
+	r.u(1)
+	assert.ErrorIs(r.err, ErrInvalidPayload)
+
+	r = &bitReader{b: []byte{0, 0, 0, 0, 0x01}}
+	r.ue()
+	assert.ErrorIs(r.err, ErrInvalidPayload)
+}
+
+func TestEscape(t *testing.T) {
+	_, assert := Describe(t)
+
+	tests := []struct {
+		raw     []byte
+		escaped []byte
+	}{
+		{[]byte{1, 2, 3}, []byte{1, 2, 3}},
+		{[]byte{0, 0, 1}, []byte{0, 0, 3, 1}},
+		{[]byte{0, 0, 0, 0}, []byte{0, 0, 3, 0, 0}},
+		{[]byte{0, 0, 3, 0, 0, 4}, []byte{0, 0, 3, 3, 0, 0, 4}},
+		{[]byte{0, 0}, []byte{0, 0}},
+	}
+	for i, tt := range tests {
+		assert.Equal(tt.escaped, escape(tt.raw), "sample %d", i+1)
+		assert.Equal(tt.raw, unescape(tt.escaped), "sample %d", i+1)
+	}
+}
+
+// writeUE writes the unsigned Exp-Golomb code of `v`.
+func writeUE(w *bitWriter, v uint32) {
+	n := bits.Len32(v + 1)
+	w.u(n-1, 0)
+	w.u(n, v+1)
+}
+
+// writeSE writes the signed Exp-Golomb code of `v`.
+func writeSE(w *bitWriter, v int32) {
+	if v > 0 {
+		writeUE(w, uint32(2*v-1))
+		return
+	}
+	writeUE(w, uint32(-2*v))
+}
@@ -0,0 +1,329 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package sei
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/wunderbarb/timecode"
+)
+
+const (
+	cAspectRatioExtendedSAR = 255
+	cHighProfileChroma444   = 3
+	cPicStructBits          = 4
+	cLengthBits             = 5
+	cCtTypeBits             = 2
+	cCountingTypeBits       = 5
+	cNFramesBits264         = 8
+	cSecondsBits            = 6
+	cMinutesBits            = 6
+	cHoursBits              = 5
+	cMaxPicStruct           = 8
+	// cFieldTicks is the ratio of the tick rate to the frame rate above which the ticks count fields.
+	cFieldTicks = 1.5
+)
+
+// _highProfiles are the profile_idc values of the sequence parameter sets with chroma format fields.
+var _highProfiles = map[uint32]bool{100: true, 110: true, 122: true, 244: true, 44: true, 83: true, 86: true,
+	118: true, 128: true, 138: true, 139: true, 134: true, 135: true}
+
+// _numClockTS is the number of clock timestamps of each pic_struct.
+var _numClockTS = [cMaxPicStruct + 1]int{1, 1, 1, 2, 2, 3, 3, 2, 3}
+
+// SPS holds the fields of an H.264 sequence parameter set needed by the pic_timing SEI message.
+type SPS struct {
+	// CpbDpbDelaysPresent is true if the NAL or VCL HRD parameters are present.
+	CpbDpbDelaysPresent bool
+	// CpbRemovalDelayLength is the number of bits of cpb_removal_delay.
+	CpbRemovalDelayLength uint8
+	// DpbOutputDelayLength is the number of bits of dpb_output_delay.
+	DpbOutputDelayLength uint8
+	// TimeOffsetLength is the number of bits of time_offset.
+	TimeOffsetLength uint8
+	// PicStructPresent is true if the pic_timing messages have a pic_struct and clock timestamps.
+	PicStructPresent bool
+	// NumUnitsInTick and TimeScale are the VUI timing information or 0 if absent.
+	NumUnitsInTick uint32
+	TimeScale      uint32
+}
+
+// PicTiming is an H.264 pic_timing SEI message.
+type PicTiming struct {
+	CpbRemovalDelay uint32
+	DpbOutputDelay  uint32
+	// PicStruct is the pic_struct, e.g., 0 for a frame.
+	PicStruct uint8
+	// ClockTimestamps are the clock timestamps of the pic_struct.  An absent clock timestamp is nil.
+	ClockTimestamps []*ClockTimestamp
+}
+
+// ParseSPS parses the H.264 sequence parameter set NAL unit `nal` up to its VUI parameters.
+func ParseSPS(nal []byte) (*SPS, error) {
+	if NALType(H264, nal) != cNALSPS264 {
+		return nil, errors.Wrapf(ErrInvalidPayload, "NAL unit type %d is not SPS", NALType(H264, nal))
+	}
+	r := &bitReader{b: unescape(nal[cHeader264:])}
+	profile := r.u(8)
+	r.u(16) // constraint_set flags and level_idc
+	r.ue()  // seq_parameter_set_id
+	if _highProfiles[profile] {
+		chroma := r.ue()
+		if chroma == cHighProfileChroma444 {
+			r.flag() // separate_colour_plane_flag
+		}
+		r.ue()   // bit_depth_luma_minus8
+		r.ue()   // bit_depth_chroma_minus8
+		r.flag() // qpprime_y_zero_transform_bypass_flag
+		if r.flag() {
+			lists := 8
+			if chroma == cHighProfileChroma444 {
+				lists = 12
+			}
+			for i := 0; i < lists; i++ {
+				if r.flag() {
+					size := 16
+					if i >= 6 {
+						size = 64
+					}
+					skipScalingList(r, size)
+				}
+			}
+		}
+	}
+	r.ue() // log2_max_frame_num_minus4
+	switch r.ue() {
+	case 0:
+		r.ue() // log2_max_pic_order_cnt_lsb_minus4
+	case 1:
+		r.flag() // delta_pic_order_always_zero_flag
+		r.se()   // offset_for_non_ref_pic
+		r.se()   // offset_for_top_to_bottom_field
+		for n := r.ue(); n > 0 && r.err == nil; n-- {
+			r.se() // offset_for_ref_frame
+		}
+	}
+	r.ue()   // max_num_ref_frames
+	r.flag() // gaps_in_frame_num_value_allowed_flag
+	r.ue()   // pic_width_in_mbs_minus1
+	r.ue()   // pic_height_in_map_units_minus1
+	if !r.flag() {
+		r.flag() // mb_adaptive_frame_field_flag
+	}
+	r.flag() // direct_8x8_inference_flag
+	if r.flag() {
+		r.ue()
+		r.ue()
+		r.ue()
+		r.ue()
+	}
+	sps := &SPS{}
+	if r.flag() {
+		sps.parseVUI(r)
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return sps, nil
+}
+
+// parseVUI parses the VUI parameters up to pic_struct_present_flag.
+func (sps *SPS) parseVUI(r *bitReader) {
+	if r.flag() {
+		if r.u(8) == cAspectRatioExtendedSAR {
+			r.u(32) // sar_width and sar_height
+		}
+	}
+	if r.flag() {
+		r.flag() // overscan_appropriate_flag
+	}
+	if r.flag() {
+		r.u(4) // video_format and video_full_range_flag
+		if r.flag() {
+			r.u(24) // colour_primaries, transfer_characteristics and matrix_coefficients
+		}
+	}
+	if r.flag() {
+		r.ue() // chroma_sample_loc_type_top_field
+		r.ue() // chroma_sample_loc_type_bottom_field
+	}
+	if r.flag() {
+		sps.NumUnitsInTick = r.u(32)
+		sps.TimeScale = r.u(32)
+		r.flag() // fixed_frame_rate_flag
+	}
+	nal := r.flag()
+	if nal {
+		sps.parseHRD(r)
+	}
+	vcl := r.flag()
+	if vcl {
+		sps.parseHRD(r)
+	}
+	if nal || vcl {
+		sps.CpbDpbDelaysPresent = true
+		r.flag() // low_delay_hrd_flag
+	}
+	sps.PicStructPresent = r.flag()
+}
+
+// parseHRD parses HRD parameters.  The lengths of the second HRD parameters overwrite the first ones, as
+// they must be equal.
+func (sps *SPS) parseHRD(r *bitReader) {
+	n := r.ue() // cpb_cnt_minus1
+	r.u(8)      // bit_rate_scale and cpb_size_scale
+	for i := uint32(0); i <= n && r.err == nil; i++ {
+		r.ue()   // bit_rate_value_minus1
+		r.ue()   // cpb_size_value_minus1
+		r.flag() // cbr_flag
+	}
+	r.u(cLengthBits) // initial_cpb_removal_delay_length_minus1
+	sps.CpbRemovalDelayLength = uint8(r.u(cLengthBits)) + 1
+	sps.DpbOutputDelayLength = uint8(r.u(cLengthBits)) + 1
+	sps.TimeOffsetLength = uint8(r.u(cLengthBits))
+}
+
+// ParsePicTiming parses the payload of a pic_timing SEI message of a stream with the sequence parameter
+// set `sps`.
+func ParsePicTiming(sps *SPS, payload []byte) (*PicTiming, error) {
+	r := &bitReader{b: payload}
+	pt := &PicTiming{}
+	if sps.CpbDpbDelaysPresent {
+		pt.CpbRemovalDelay = r.u(int(sps.CpbRemovalDelayLength))
+		pt.DpbOutputDelay = r.u(int(sps.DpbOutputDelayLength))
+	}
+	if sps.PicStructPresent {
+		pt.PicStruct = uint8(r.u(cPicStructBits))
+		if pt.PicStruct > cMaxPicStruct {
+			return nil, errors.Wrapf(ErrInvalidPayload, "pic_struct %d", pt.PicStruct)
+		}
+		for i := 0; i < _numClockTS[pt.PicStruct]; i++ {
+			var c *ClockTimestamp
+			if r.flag() {
+				c = &ClockTimestamp{CtType: uint8(r.u(cCtTypeBits)), TimeOffsetLength: sps.TimeOffsetLength}
+				c.read(r, cNFramesBits264)
+				c.readTimeOffset(r)
+			}
+			pt.ClockTimestamps = append(pt.ClockTimestamps, c)
+		}
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return pt, nil
+}
+
+// NewPicTiming returns a pic_timing SEI message for a frame, i.e., pic_struct 0, with the clock timestamp
+// of the timecode `tc`.  The nuit_field_based_flag is set if the VUI timing counts fields, i.e., if the
+// time scale is twice the frame rate.
+func NewPicTiming(sps *SPS, tc timecode.Timecode) *PicTiming {
+	c := NewClockTimestamp(tc)
+	c.NuitFieldBased = sps.NumUnitsInTick > 0 &&
+		float64(sps.TimeScale)/float64(sps.NumUnitsInTick) > cFieldTicks*tc.Rate().FPS
+	c.TimeOffsetLength = sps.TimeOffsetLength
+	return &PicTiming{ClockTimestamps: []*ClockTimestamp{c}}
+}
+
+// Bytes returns the payload of the pic_timing SEI message for a stream with the sequence parameter set
+// `sps`.
+func (pt *PicTiming) Bytes(sps *SPS) []byte {
+	w := &bitWriter{}
+	if sps.CpbDpbDelaysPresent {
+		w.u(int(sps.CpbRemovalDelayLength), pt.CpbRemovalDelay)
+		w.u(int(sps.DpbOutputDelayLength), pt.DpbOutputDelay)
+	}
+	if sps.PicStructPresent {
+		w.u(cPicStructBits, uint32(pt.PicStruct))
+		for i := 0; i < _numClockTS[pt.PicStruct%(cMaxPicStruct+1)]; i++ {
+			var c *ClockTimestamp
+			if i < len(pt.ClockTimestamps) {
+				c = pt.ClockTimestamps[i]
+			}
+			w.flag(c != nil)
+			if c != nil {
+				w.u(cCtTypeBits, uint32(c.CtType))
+				c.write(w, cNFramesBits264)
+				c.writeTimeOffset(w, sps.TimeOffsetLength)
+			}
+		}
+	}
+	w.align()
+	return w.b
+}
+
+// read reads the clock timestamp fields from nuit_field_based_flag to the hours with n_frames of
+// `nFramesBits` bits.
+func (c *ClockTimestamp) read(r *bitReader, nFramesBits int) {
+	c.NuitFieldBased = r.flag()
+	c.CountingType = uint8(r.u(cCountingTypeBits))
+	c.FullTimestamp = r.flag()
+	c.Discontinuity = r.flag()
+	c.CntDropped = r.flag()
+	c.NFrames = uint16(r.u(nFramesBits))
+	if c.FullTimestamp {
+		c.Seconds = uint8(r.u(cSecondsBits))
+		c.Minutes = uint8(r.u(cMinutesBits))
+		c.Hours = uint8(r.u(cHoursBits))
+	} else if c.SecondsFlag = r.flag(); c.SecondsFlag {
+		c.Seconds = uint8(r.u(cSecondsBits))
+		if c.MinutesFlag = r.flag(); c.MinutesFlag {
+			c.Minutes = uint8(r.u(cMinutesBits))
+			if c.HoursFlag = r.flag(); c.HoursFlag {
+				c.Hours = uint8(r.u(cHoursBits))
+			}
+		}
+	}
+}
+
+// readTimeOffset reads the time offset of TimeOffsetLength bits.
+func (c *ClockTimestamp) readTimeOffset(r *bitReader) {
+	if c.TimeOffsetLength > 0 {
+		c.TimeOffset = r.i(int(c.TimeOffsetLength))
+	}
+}
+
+// write writes the clock timestamp fields from nuit_field_based_flag to the hours with n_frames of
+// `nFramesBits` bits.
+func (c *ClockTimestamp) write(w *bitWriter, nFramesBits int) {
+	w.flag(c.NuitFieldBased)
+	w.u(cCountingTypeBits, uint32(c.CountingType))
+	w.flag(c.FullTimestamp)
+	w.flag(c.Discontinuity)
+	w.flag(c.CntDropped)
+	w.u(nFramesBits, uint32(c.NFrames))
+	if c.FullTimestamp {
+		w.u(cSecondsBits, uint32(c.Seconds))
+		w.u(cMinutesBits, uint32(c.Minutes))
+		w.u(cHoursBits, uint32(c.Hours))
+	} else if w.flag(c.SecondsFlag); c.SecondsFlag {
+		w.u(cSecondsBits, uint32(c.Seconds))
+		if w.flag(c.MinutesFlag); c.MinutesFlag {
+			w.u(cMinutesBits, uint32(c.Minutes))
+			if w.flag(c.HoursFlag); c.HoursFlag {
+				w.u(cHoursBits, uint32(c.Hours))
+			}
+		}
+	}
+}
+
+// writeTimeOffset writes the time offset on `timeOffsetLength` bits.
+func (c *ClockTimestamp) writeTimeOffset(w *bitWriter, timeOffsetLength uint8) {
+	if timeOffsetLength > 0 {
+		w.u(int(timeOffsetLength), uint32(c.TimeOffset))
+	}
+}
+
+// skipScalingList skips a scaling list of `size` coefficients.
+func skipScalingList(r *bitReader, size int) {
+	last, next := int32(8), int32(8)
+	for j := 0; j < size && r.err == nil; j++ {
+		if next != 0 {
+			next = (last + r.se() + 256) % 256
+		}
+		if next != 0 {
+			last = next
+		}
+	}
+}
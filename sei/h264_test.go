@@ -0,0 +1,145 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package sei
+
+import (
+	"testing"
+
+	"github.com/wunderbarb/timecode"
+)
+
+func TestParseSPS(t *testing.T) {
+	require, assert := Describe(t)
+
+	sps, err := ParseSPS(newSPS(100, true, true))
+	require.NoError(err)
+	assert.Equal(&SPS{CpbDpbDelaysPresent: true, CpbRemovalDelayLength: 16, DpbOutputDelayLength: 5,
+		TimeOffsetLength: 24, PicStructPresent: true, NumUnitsInTick: 1001, TimeScale: 60000}, sps)
+
+	sps, err = ParseSPS(newSPS(66, false, true))
+	require.NoError(err)
+	assert.Equal(&SPS{PicStructPresent: true, NumUnitsInTick: 1001, TimeScale: 60000}, sps)
+
+	_, err = ParseSPS([]byte{cNALSEI264, 0x42})
+	assert.ErrorIs(err, ErrInvalidPayload)
+	_, err = ParseSPS(newSPS(100, true, true)[:20])
+	assert.ErrorIs(err, ErrInvalidPayload)
+}
+
+func TestPicTiming(t *testing.T) {
+	require, assert := Describe(t)
+
+	tests := []struct {
+		hrd bool
+		r   timecode.Rate
+		tc  string
+	}{
+		{true, timecode.Rate2997DF, "01:23:45;12"},
+		{false, timecode.Rate2997DF, "00:10:00;00"},
+		{true, timecode.Rate24, "23:59:59:23"},
+	}
+	for i, tt := range tests {
+		sps, err := ParseSPS(newSPS(100, tt.hrd, true))
+		require.NoError(err, "sample %d", i+1)
+		tc, err := newTimecode(tt.r, tt.tc)
+		require.NoError(err, "sample %d", i+1)
+		pt := NewPicTiming(sps, *tc)
+		pt.CpbRemovalDelay = 2
+		pt.ClockTimestamps[0].TimeOffset = -3
+		pt1, err := ParsePicTiming(sps, pt.Bytes(sps))
+		require.NoError(err, "sample %d", i+1)
+		require.Len(pt1.ClockTimestamps, 1, "sample %d", i+1)
+		c := pt1.ClockTimestamps[0]
+		assert.True(c.NuitFieldBased, "sample %d", i+1)
+		tc1, err := c.Timecode(tt.r.FPS)
+		require.NoError(err, "sample %d", i+1)
+		assert.Equal(tt.tc, tc1.String(), "sample %d", i+1)
+		if tt.hrd {
+			assert.Equal(uint32(2), pt1.CpbRemovalDelay, "sample %d", i+1)
+			assert.Equal(int32(-3), c.TimeOffset, "sample %d", i+1)
+		}
+	}
+
+	sps, _ := ParseSPS(newSPS(66, false, true))
+	pt := &PicTiming{PicStruct: 3, ClockTimestamps: []*ClockTimestamp{nil,
+		{NFrames: 7, SecondsFlag: true, Seconds: 4}}}
+	pt1, err := ParsePicTiming(sps, pt.Bytes(sps))
+	require.NoError(err)
+	assert.Equal(pt, pt1)
+	_, err = ParsePicTiming(sps, []byte{0x90})
+	assert.ErrorIs(err, ErrInvalidPayload)
+	_, err = ParsePicTiming(sps, []byte{0x08})
+	assert.ErrorIs(err, ErrInvalidPayload)
+}
+
+// newSPS returns an SPS NAL unit of profile `profile` with VUI timing at 59.94 ticks per second, NAL
+// HRD parameters if `hrd` and the pic_struct_present_flag `picStruct`.
+func newSPS(profile uint32, hrd bool, picStruct bool) []byte {
+	w := &bitWriter{}
+	w.u(8, profile)
+	w.u(16, 0x0028) // constraint_set flags and level_idc
+	writeUE(w, 0)
+	if _highProfiles[profile] {
+		writeUE(w, 1)
+		writeUE(w, 0)
+		writeUE(w, 0)
+		w.flag(false)
+		w.flag(true) // seq_scaling_matrix_present_flag
+		w.flag(true) // seq_scaling_list_present_flag[0]
+		for j := 0; j < 16; j++ {
+			writeSE(w, 0)
+		}
+		for i := 1; i < 8; i++ {
+			w.flag(false)
+		}
+	}
+	writeUE(w, 0)
+	writeUE(w, 0)
+	writeUE(w, 0)
+	writeUE(w, 4)
+	w.flag(false)
+	writeUE(w, 119)
+	writeUE(w, 67)
+	w.flag(true)
+	w.flag(true)
+	w.flag(true) // frame_cropping_flag
+	for i := 0; i < 4; i++ {
+		writeUE(w, uint32(i))
+	}
+	w.flag(true) // vui_parameters_present_flag
+	w.flag(true)
+	w.u(8, cAspectRatioExtendedSAR)
+	w.u(32, 0x00010001)
+	w.flag(false)
+	w.flag(true)
+	w.u(4, 5)
+	w.flag(true)
+	w.u(24, 0x010101)
+	w.flag(false)
+	w.flag(true) // timing_info_present_flag
+	w.u(32, 1001)
+	w.u(32, 60000)
+	w.flag(true)
+	w.flag(hrd)
+	if hrd {
+		writeUE(w, 0)
+		w.u(8, 0x44)
+		writeUE(w, 1000)
+		writeUE(w, 2000)
+		w.flag(false)
+		w.u(cLengthBits, 23)
+		w.u(cLengthBits, 15)
+		w.u(cLengthBits, 4)
+		w.u(cLengthBits, 24)
+	}
+	w.flag(false)
+	if hrd {
+		w.flag(false)
+	}
+	w.flag(picStruct)
+	w.flag(false) // bitstream_restriction_flag
+	w.align()
+	return append([]byte{0x67}, escape(w.b)...)
+}
@@ -0,0 +1,68 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package sei
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/wunderbarb/timecode"
+)
+
+const (
+	cNumClockTSBits = 2
+	cMaxNumClockTS  = 3
+	cNFramesBits265 = 9
+)
+
+// TimeCode is an HEVC time_code SEI message.
+type TimeCode struct {
+	// ClockTimestamps are the clock timestamps of the message.  An absent clock timestamp is nil.
+	ClockTimestamps []*ClockTimestamp
+}
+
+// ParseTimeCode parses the payload of a time_code SEI message.
+func ParseTimeCode(payload []byte) (*TimeCode, error) {
+	r := &bitReader{b: payload}
+	tc := &TimeCode{}
+	for n := r.u(cNumClockTSBits); n > 0; n-- {
+		var c *ClockTimestamp
+		if r.flag() {
+			c = &ClockTimestamp{}
+			c.read(r, cNFramesBits265)
+			c.TimeOffsetLength = uint8(r.u(cLengthBits))
+			c.readTimeOffset(r)
+		}
+		tc.ClockTimestamps = append(tc.ClockTimestamps, c)
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return tc, nil
+}
+
+// NewTimeCode returns a time_code SEI message with the clock timestamp of the timecode `tc`.
+func NewTimeCode(tc timecode.Timecode) *TimeCode {
+	return &TimeCode{ClockTimestamps: []*ClockTimestamp{NewClockTimestamp(tc)}}
+}
+
+// Bytes returns the payload of the time_code SEI message.  It fails if there are more than three clock
+// timestamps.
+func (tc *TimeCode) Bytes() ([]byte, error) {
+	if len(tc.ClockTimestamps) > cMaxNumClockTS {
+		return nil, errors.Wrapf(ErrInvalidPayload, "%d clock timestamps", len(tc.ClockTimestamps))
+	}
+	w := &bitWriter{}
+	w.u(cNumClockTSBits, uint32(len(tc.ClockTimestamps)))
+	for _, c := range tc.ClockTimestamps {
+		w.flag(c != nil)
+		if c != nil {
+			c.write(w, cNFramesBits265)
+			w.u(cLengthBits, uint32(c.TimeOffsetLength))
+			c.writeTimeOffset(w, c.TimeOffsetLength)
+		}
+	}
+	w.align()
+	return w.b, nil
+}
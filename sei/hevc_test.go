@@ -0,0 +1,49 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package sei
+
+import (
+	"testing"
+
+	"github.com/wunderbarb/timecode"
+)
+
+func TestTimeCode(t *testing.T) {
+	require, assert := Describe(t)
+
+	tests := []struct {
+		r  timecode.Rate
+		tc string
+	}{
+		{timecode.Rate2997DF, "10:00:00;02"},
+		{timecode.Rate25, "00:00:59:24"},
+		{timecode.Rate{FPS: 60}, "12:00:00:59"},
+	}
+	for i, tt := range tests {
+		tc, err := newTimecode(tt.r, tt.tc)
+		require.NoError(err, "sample %d", i+1)
+		b, err := NewTimeCode(*tc).Bytes()
+		require.NoError(err, "sample %d", i+1)
+		tc1, err := ParseTimeCode(b)
+		require.NoError(err, "sample %d", i+1)
+		require.Len(tc1.ClockTimestamps, 1, "sample %d", i+1)
+		tc2, err := tc1.ClockTimestamps[0].Timecode(tt.r.FPS)
+		require.NoError(err, "sample %d", i+1)
+		assert.Equal(tt.tc, tc2.String(), "sample %d", i+1)
+	}
+
+	c := &TimeCode{ClockTimestamps: []*ClockTimestamp{nil, {NFrames: 300, SecondsFlag: true, Seconds: 1,
+		MinutesFlag: true, Minutes: 2, TimeOffsetLength: 7, TimeOffset: -5}}}
+	b, err := c.Bytes()
+	require.NoError(err)
+	c1, err := ParseTimeCode(b)
+	require.NoError(err)
+	assert.Equal(c, c1)
+
+	_, err = (&TimeCode{ClockTimestamps: make([]*ClockTimestamp, 4)}).Bytes()
+	assert.ErrorIs(err, ErrInvalidPayload)
+	_, err = ParseTimeCode([]byte{0x60})
+	assert.ErrorIs(err, ErrInvalidPayload)
+}
@@ -0,0 +1,308 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+// Package sei extracts and generates the per frame timecodes of H.264 `pic_timing` and HEVC `time_code`
+// SEI messages.  It splits Annex B byte streams in NAL units, decodes the SEI messages and converts their
+// clock timestamps from and to timecode.Timecode.
+//
+// The H.264 pic_timing message depends on the HRD and VUI parameters of the sequence parameter set,
+// which ParseSPS decodes.
+package sei
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+
+	"github.com/wunderbarb/timecode"
+)
+
+var (
+	// ErrInvalidPayload is returned when a NAL unit or an SEI payload is truncated or malformed.
+	ErrInvalidPayload = errors.New("sei: invalid payload")
+	// ErrNoSPS is returned when an H.264 pic_timing message precedes any sequence parameter set.
+	ErrNoSPS = errors.New("sei: no sequence parameter set")
+)
+
+// Codec is the video coding standard of a stream.
+type Codec int
+
+const (
+	// H264 is H.264, i.e., AVC.
+	H264 Codec = iota
+	// HEVC is H.265, i.e., HEVC.
+	HEVC
+)
+
+const (
+	// PayloadPicTiming is the payload type of the H.264 pic_timing SEI message.
+	PayloadPicTiming = 1
+	// PayloadTimeCode is the payload type of the HEVC time_code SEI message.
+	PayloadTimeCode = 136
+	// CountingDropFrame is the counting_type of the drop frame timecodes.
+	CountingDropFrame = 4
+)
+
+const (
+	cNALSEI264       = 6
+	cNALSPS264       = 7
+	cNALPrefixSEI265 = 39
+	cNALSuffixSEI265 = 40
+	cHeader264       = 1
+	cHeader265       = 2
+	cFF              = 0xFF
+	cTrailingBits    = 0x80
+)
+
+// ClockTimestamp is a clock timestamp of a pic_timing or time_code SEI message.
+type ClockTimestamp struct {
+	// CtType is the H.264 ct_type, i.e., 0 progressive, 1 interlaced and 2 unknown.  It is not in HEVC.
+	CtType uint8
+	// NuitFieldBased is the nuit_field_based_flag of H.264 or the units_field_based_flag of HEVC.
+	NuitFieldBased bool
+	// CountingType is the counting_type, e.g., CountingDropFrame.
+	CountingType uint8
+	// FullTimestamp is true if the seconds, minutes and hours are all present.
+	FullTimestamp bool
+	// Discontinuity is the discontinuity_flag.
+	Discontinuity bool
+	// CntDropped is the cnt_dropped_flag.
+	CntDropped bool
+	// NFrames is the number of frames of the label.
+	NFrames uint16
+	// SecondsFlag, MinutesFlag and HoursFlag tell which fields are present when FullTimestamp is false.
+	// The minutes are present only with the seconds and the hours only with the minutes.
+	SecondsFlag bool
+	MinutesFlag bool
+	HoursFlag   bool
+	Seconds     uint8
+	Minutes     uint8
+	Hours       uint8
+	// TimeOffsetLength is the number of bits of the time offset.  In H.264, it is set by the HRD
+	// parameters.
+	TimeOffsetLength uint8
+	// TimeOffset is the time offset in clock ticks.
+	TimeOffset int32
+}
+
+// Message is an SEI message.
+type Message struct {
+	Type    int
+	Payload []byte
+}
+
+// NewClockTimestamp returns the full clock timestamp of the label of the timecode `tc`.  The counting
+// type is CountingDropFrame in drop frame and 0 otherwise.
+func NewClockTimestamp(tc timecode.Timecode) *ClockTimestamp {
+	h, m, s, f := tc.Fields()
+	c := &ClockTimestamp{FullTimestamp: true, NFrames: uint16(f), Seconds: uint8(s), Minutes: uint8(m),
+		Hours: uint8(h)}
+	if tc.Rate().DropFrame {
+		c.CountingType = CountingDropFrame
+	}
+	return c
+}
+
+// Timecode returns the timecode of the clock timestamp at the frame rate `fps`.  It is drop frame if the
+// counting type is CountingDropFrame.  The absent fields of a partial timestamp are 0.
+func (c *ClockTimestamp) Timecode(fps float64) (*timecode.Timecode, error) {
+	r := timecode.Rate{FPS: fps, DropFrame: c.CountingType == CountingDropFrame}
+	return timecode.NewFromFields(r, int(c.Hours), int(c.Minutes), int(c.Seconds), int(c.NFrames))
+}
+
+// inherit completes a partial clock timestamp with the fields of the previous clock timestamp `prev`.
+func (c *ClockTimestamp) inherit(prev *ClockTimestamp) {
+	if c.FullTimestamp || prev == nil {
+		return
+	}
+	if !c.SecondsFlag {
+		c.Seconds = prev.Seconds
+	}
+	if !c.MinutesFlag {
+		c.Minutes = prev.Minutes
+	}
+	if !c.HoursFlag {
+		c.Hours = prev.Hours
+	}
+}
+
+// NALUnits returns the NAL units of the Annex B byte stream `stream` without their start codes.
+func NALUnits(stream []byte) [][]byte {
+	var nals [][]byte
+	start := -1
+	for i := 0; i+2 < len(stream); i++ {
+		if stream[i] != 0 || stream[i+1] != 0 || stream[i+2] != 1 {
+			continue
+		}
+		if start >= 0 {
+			nals = append(nals, bytes.TrimRight(stream[start:i], "\x00"))
+		}
+		start = i + 3
+		i += 2
+	}
+	if start >= 0 && start < len(stream) {
+		nals = append(nals, stream[start:])
+	}
+	return nals
+}
+
+// NALType returns the nal_unit_type of the NAL unit `nal` or -1 if it is empty.
+func NALType(codec Codec, nal []byte) int {
+	if len(nal) == 0 {
+		return -1
+	}
+	if codec == HEVC {
+		return int(nal[0]>>1) & 0x3F
+	}
+	return int(nal[0]) & 0x1F
+}
+
+// IsSEI returns true if the NAL unit `nal` is an SEI NAL unit.
+func IsSEI(codec Codec, nal []byte) bool {
+	t := NALType(codec, nal)
+	if codec == HEVC {
+		return t == cNALPrefixSEI265 || t == cNALSuffixSEI265
+	}
+	return t == cNALSEI264
+}
+
+// ParseMessages returns the SEI messages of the SEI NAL unit `nal`.
+func ParseMessages(codec Codec, nal []byte) ([]Message, error) {
+	if !IsSEI(codec, nal) || len(nal) < headerSize(codec) {
+		return nil, errors.Wrapf(ErrInvalidPayload, "NAL unit type %d is not SEI", NALType(codec, nal))
+	}
+	b := unescape(nal[headerSize(codec):])
+	var msgs []Message
+	for len(b) > 0 && !(len(b) == 1 && b[0] == cTrailingBits) {
+		var typ, size int
+		var ok bool
+		if typ, b, ok = readFF(b); !ok {
+			return nil, errors.Wrapf(ErrInvalidPayload, "truncated payload type")
+		}
+		if size, b, ok = readFF(b); !ok || size > len(b) {
+			return nil, errors.Wrapf(ErrInvalidPayload, "payload type %d of %d bytes", typ, size)
+		}
+		msgs = append(msgs, Message{Type: typ, Payload: b[:size]})
+		b = b[size:]
+	}
+	return msgs, nil
+}
+
+// NewNALUnit returns the SEI NAL unit, without start code, of the messages `msgs`.  The H.264 NAL unit has
+// a nal_ref_idc of 0 and the HEVC NAL unit is a prefix SEI of layer 0 and temporal id 0.
+func NewNALUnit(codec Codec, msgs ...Message) []byte {
+	var rbsp []byte
+	for _, m := range msgs {
+		rbsp = appendFF(rbsp, m.Type)
+		rbsp = appendFF(rbsp, len(m.Payload))
+		rbsp = append(rbsp, m.Payload...)
+	}
+	rbsp = append(rbsp, cTrailingBits)
+	nal := []byte{cNALSEI264}
+	if codec == HEVC {
+		nal = []byte{cNALPrefixSEI265 << 1, 1}
+	}
+	return append(nal, escape(rbsp)...)
+}
+
+// Timecodes returns the timecodes of the first clock timestamps of the pic_timing or time_code SEI
+// messages of the Annex B byte stream `stream` at the frame rate `fps`.  A partial clock timestamp takes
+// its absent fields from the previous clock timestamp.
+func Timecodes(codec Codec, stream []byte, fps float64) ([]timecode.Timecode, error) {
+	var (
+		sps  *SPS
+		prev *ClockTimestamp
+		tcs  []timecode.Timecode
+	)
+	for _, nal := range NALUnits(stream) {
+		if codec == H264 && NALType(codec, nal) == cNALSPS264 {
+			var err error
+			if sps, err = ParseSPS(nal); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if !IsSEI(codec, nal) {
+			continue
+		}
+		msgs, err := ParseMessages(codec, nal)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range msgs {
+			c, err := firstClockTimestamp(codec, sps, m)
+			if err != nil {
+				return nil, err
+			}
+			if c == nil {
+				continue
+			}
+			c.inherit(prev)
+			prev = c
+			tc, err := c.Timecode(fps)
+			if err != nil {
+				return nil, errors.Wrapf(err, "SEI %d", len(tcs)+1)
+			}
+			tcs = append(tcs, *tc)
+		}
+	}
+	return tcs, nil
+}
+
+// firstClockTimestamp returns the first clock timestamp of the message `m` or nil if there is none.
+func firstClockTimestamp(codec Codec, sps *SPS, m Message) (*ClockTimestamp, error) {
+	var cts []*ClockTimestamp
+	switch {
+	case codec == H264 && m.Type == PayloadPicTiming:
+		if sps == nil {
+			return nil, ErrNoSPS
+		}
+		pt, err := ParsePicTiming(sps, m.Payload)
+		if err != nil {
+			return nil, err
+		}
+		cts = pt.ClockTimestamps
+	case codec == HEVC && m.Type == PayloadTimeCode:
+		tc, err := ParseTimeCode(m.Payload)
+		if err != nil {
+			return nil, err
+		}
+		cts = tc.ClockTimestamps
+	}
+	for _, c := range cts {
+		if c != nil {
+			return c, nil
+		}
+	}
+	return nil, nil
+}
+
+func headerSize(codec Codec) int {
+	if codec == HEVC {
+		return cHeader265
+	}
+	return cHeader264
+}
+
+// readFF reads a payload type or size coded as a run of 0xFF bytes and a last byte.
+func readFF(b []byte) (int, []byte, bool) {
+	v := 0
+	for len(b) > 0 {
+		c := b[0]
+		b = b[1:]
+		v += int(c)
+		if c != cFF {
+			return v, b, true
+		}
+	}
+	return 0, nil, false
+}
+
+// appendFF appends the payload type or size `v` coded as a run of 0xFF bytes and a last byte.
+func appendFF(b []byte, v int) []byte {
+	for ; v >= cFF; v -= cFF {
+		b = append(b, cFF)
+	}
+	return append(b, byte(v))
+}
@@ -0,0 +1,153 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package sei
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wunderbarb/timecode"
+)
+
+var testCounter int
+
+var _startCode = []byte{0, 0, 0, 1}
+
+func TestTimecodes(t *testing.T) {
+	require, assert := Describe(t)
+
+	labels := []string{"00:59:59;28", "00:59:59;29", "01:00:00;00", "01:00:00;01"}
+	sps, err := ParseSPS(newSPS(100, true, true))
+	require.NoError(err)
+	stream264 := [][]byte{newSPS(100, true, true)}
+	var stream265 [][]byte
+	for _, l := range labels {
+		tc, err := newTimecode(timecode.Rate2997DF, l)
+		require.NoError(err)
+		b, err := NewTimeCode(*tc).Bytes()
+		require.NoError(err)
+		stream264 = append(stream264, NewNALUnit(H264, Message{Type: PayloadPicTiming,
+			Payload: NewPicTiming(sps, *tc).Bytes(sps)}), []byte{0x65, 0x88, 0x80})
+		stream265 = append(stream265, NewNALUnit(HEVC, Message{Type: 5, Payload: []byte{0, 0, 0}},
+			Message{Type: PayloadTimeCode, Payload: b}), []byte{0x26, 0x01, 0xAF})
+	}
+	tests := []struct {
+		codec  Codec
+		stream [][]byte
+	}{
+		{H264, stream264},
+		{HEVC, stream265},
+	}
+	for i, tt := range tests {
+		tcs, err := Timecodes(tt.codec, newStream(tt.stream...), timecode.FPS2997)
+		require.NoError(err, "sample %d", i+1)
+		require.Len(tcs, len(labels), "sample %d", i+1)
+		for j, tc := range tcs {
+			assert.Equal(labels[j], tc.String(), "sample %d", i+1)
+		}
+	}
+
+	_, err = Timecodes(H264, newStream(stream264[1:]...), timecode.FPS2997)
+	assert.ErrorIs(err, ErrNoSPS)
+}
+
+func TestTimecodes_partial(t *testing.T) {
+	require, assert := Describe(t)
+
+	full := &ClockTimestamp{FullTimestamp: true, NFrames: 23, Seconds: 59, Minutes: 59, Hours: 1}
+	partial := []*ClockTimestamp{full, {NFrames: 0, SecondsFlag: true, MinutesFlag: true, HoursFlag: true,
+		Hours: 2}, {NFrames: 1}, {NFrames: 2, SecondsFlag: true, Seconds: 1}}
+	var nals [][]byte
+	for _, c := range partial {
+		b, err := (&TimeCode{ClockTimestamps: []*ClockTimestamp{c}}).Bytes()
+		require.NoError(err)
+		nals = append(nals, NewNALUnit(HEVC, Message{Type: PayloadTimeCode, Payload: b}))
+	}
+	tcs, err := Timecodes(HEVC, newStream(nals...), 24)
+	require.NoError(err)
+	var got []string
+	for _, tc := range tcs {
+		got = append(got, tc.String())
+	}
+	assert.Equal([]string{"01:59:59:23", "02:00:00:00", "02:00:00:01", "02:00:01:02"}, got)
+}
+
+func TestNALUnits(t *testing.T) {
+	_, assert := Describe(t)
+
+	tests := []struct {
+		stream []byte
+		exp    [][]byte
+	}{
+		{nil, nil},
+		{[]byte{1, 2, 3}, nil},
+		{[]byte{0, 0, 1, 6, 5, 0, 0, 0, 1, 0x65, 0, 0, 1, 0x41}, [][]byte{{6, 5}, {0x65}, {0x41}}},
+		{[]byte{0, 0, 0, 1, 0x67, 0, 0, 3, 1, 0}, [][]byte{{0x67, 0, 0, 3, 1, 0}}},
+	}
+	for i, tt := range tests {
+		assert.Equal(tt.exp, NALUnits(tt.stream), "sample %d", i+1)
+	}
+}
+
+func TestParseMessages(t *testing.T) {
+	require, assert := Describe(t)
+
+	big := bytes.Repeat([]byte{0}, 300)
+	msgs := []Message{{Type: PayloadPicTiming, Payload: []byte{1, 2}}, {Type: 300, Payload: big}}
+	for i, codec := range []Codec{H264, HEVC} {
+		nal := NewNALUnit(codec, msgs...)
+		assert.True(IsSEI(codec, nal), "sample %d", i+1)
+		got, err := ParseMessages(codec, nal)
+		require.NoError(err, "sample %d", i+1)
+		assert.Equal(msgs, got, "sample %d", i+1)
+	}
+
+	_, err := ParseMessages(H264, []byte{0x65, 1})
+	assert.ErrorIs(err, ErrInvalidPayload)
+	_, err = ParseMessages(H264, []byte{cNALSEI264, 1, 5, 0})
+	assert.ErrorIs(err, ErrInvalidPayload)
+	_, err = ParseMessages(H264, []byte{cNALSEI264, 0xFF})
+	assert.ErrorIs(err, ErrInvalidPayload)
+	assert.Equal(-1, NALType(HEVC, nil))
+}
+
+// newTimecode returns the timecode of the label `s` at the rate `r`.
+func newTimecode(r timecode.Rate, s string) (*timecode.Timecode, error) {
+	tc, err := timecode.NewFromRate(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err = tc.Parse(s); err != nil {
+		return nil, err
+	}
+	return tc, nil
+}
+
+// newStream returns the Annex B byte stream of the NAL units `nals`.
+func newStream(nals ...[]byte) []byte {
+	var b []byte
+	for _, nal := range nals {
+		b = append(append(b, _startCode...), nal...)
+	}
+	return b
+}
+
+func Describe(t *testing.T, msg ...string) (*require.Assertions,
+	*assert.Assertions) {
+
+	dispMsg := ""
+	if len(msg) != 0 {
+		dispMsg = msg[0]
+	}
+	name := strings.TrimPrefix(strings.TrimPrefix(t.Name(), "Test"), "_")
+	fmt.Printf("Test %d: %s %s\n", testCounter, name, dispMsg)
+	testCounter++
+	return require.New(t), assert.New(t)
+}
@@ -0,0 +1,73 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+const (
+	// cFlagDropFrame12M is the drop frame flag of the frames byte of a SMPTE 12M timecode.
+	cFlagDropFrame12M = 0x40
+	// cFieldMark12M is the field mark of the seconds byte of a SMPTE 12M timecode.  Above 30 FPS, it flags
+	// the second frame of a frame pair.
+	cFieldMark12M = 0x80 << 8
+	cMax12MBase   = 30
+	cBCDBase      = 10
+	cNibble       = 4
+	cByte         = 8
+)
+
+// NewFromSMPTE12M initializes a Timecode structure with the frame rate of `r` from the 32-bit SMPTE 12M
+// timecode `v`, i.e., the frames, seconds, minutes and hours in binary coded decimal from the least
+// significant byte, as found in MXF system items and the OpenEXR `timeCode` attribute.  The drop frame
+// flag of `v` sets the drop frame mode.  Above 30 FPS, the frames count frame pairs and the field mark
+// flags the second frame of the pair.  The other flags and the binary group flags are ignored.
+func NewFromSMPTE12M(r Rate, v uint32) (*Timecode, error) {
+	r.DropFrame = v&cFlagDropFrame12M != 0
+	if !r.Valid() {
+		return nil, ErrInvalidFPS
+	}
+	f, okF := fromBCD(v & 0x3F)
+	s, okS := fromBCD(v >> cByte & 0x7F)
+	m, okM := fromBCD(v >> (2 * cByte) & 0x7F)
+	h, okH := fromBCD(v >> (3 * cByte) & 0x3F)
+	if !okF || !okS || !okM || !okH {
+		return nil, ErrInvalidTimeCode
+	}
+	if newFrameTable(r).timeBase > cMax12MBase {
+		f *= 2
+		if v&cFieldMark12M != 0 {
+			f++
+		}
+	}
+	return NewFromFields(r, h, m, s, f)
+}
+
+// SMPTE12M returns the label of the timecode, wrapped at 24 hours, as a 32-bit SMPTE 12M timecode with
+// the drop frame flag.  Above 30 FPS, the frames count frame pairs and the field mark flags the second
+// frame of the pair.
+func (t *Timecode) SMPTE12M() uint32 {
+	h, m, s, f := t.Fields()
+	var v uint32
+	if t.table().timeBase > cMax12MBase {
+		if f%2 == 1 {
+			v |= cFieldMark12M
+		}
+		f /= 2
+	}
+	if t.dropFrame {
+		v |= cFlagDropFrame12M
+	}
+	return v | toBCD(f) | toBCD(s)<<cByte | toBCD(m)<<(2*cByte) | toBCD(h)<<(3*cByte)
+}
+
+// fromBCD returns the value of the two binary coded decimal digits of `v` and false if a digit is not
+// decimal.
+func fromBCD(v uint32) (int, bool) {
+	hi, lo := int(v>>cNibble), int(v&0xF)
+	return hi*cBCDBase + lo, hi < cBCDBase && lo < cBCDBase
+}
+
+// toBCD returns the two binary coded decimal digits of `n`, with 0 <= n < 100.
+func toBCD(n int) uint32 {
+	return uint32(n/cBCDBase<<cNibble | n%cBCDBase)
+}
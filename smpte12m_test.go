@@ -0,0 +1,54 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"testing"
+)
+
+func TestNewFromSMPTE12M(t *testing.T) {
+	require, assert := Describe(t)
+
+	tests := []struct {
+		r          Rate
+		v          uint32
+		exp        string
+		expSuccess bool
+	}{
+		{Rate25, 0x12345622, "12:34:56:22", true},
+		{Rate2997, 0x01000040, "01:00:00;00", true},
+		{Rate2997DF, 0x01000000, "01:00:00:00", true},
+		{Rate25, 0xC0000080, "00:00:00:00", true},
+		{Rate{FPS: 50}, 0x00008024, "00:00:00:49", true},
+		{Rate{FPS: 50}, 0x00000024, "00:00:00:48", true},
+		{Rate25, 0x0000000A, "", false},
+		{Rate25, 0x00006000, "", false},
+		{Rate25, 0x24000000, "", false},
+		{Rate25, 0x00000025, "", false},
+		{Rate25, 0x00000040, "", false},
+		{Rate2997, 0x00010040, "", false},
+	}
+	for i, tt := range tests {
+		tc, err := NewFromSMPTE12M(tt.r, tt.v)
+		require.Equal(tt.expSuccess, err == nil, "sample %d", i+1)
+		if err == nil {
+			assert.Equal(tt.exp, tc.String(), "sample %d", i+1)
+			assert.Equal(tt.v&^0xC0000080|tt.v&cFlagDropFrame12M, tc.SMPTE12M(), "sample %d", i+1)
+		}
+	}
+}
+
+func TestTimecode_SMPTE12M(t *testing.T) {
+	_, assert := Describe(t)
+
+	for _, r := range []Rate{Rate23976, Rate25, Rate2997DF, {FPS: 60}} {
+		for _, f := range []int{0, 1, 17982, 107891, 2589407} {
+			tc, _ := NewFromRate(r, f)
+			tc1, err := NewFromSMPTE12M(r, tc.SMPTE12M())
+			assert.NoError(err, "%v frame %d", r, f)
+			assert.Equal(tc.Frame()%tc.framesPerDay(), tc1.Frame(), "%v frame %d", r, f)
+		}
+	}
+}
@@ -0,0 +1,357 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+// Package subtitle reads and writes SubRip (SRT), WebVTT and Scenarist (SCC) subtitle files.  The cue
+// timings are timecode.Timecode values so that a file can be retimed by a timecode offset or converted
+// to another frame rate.
+//
+// The timestamps of SRT and WebVTT are quantized to the closest frame of the rate of the file.
+// The SCC cues carry raw CEA-608 byte pairs.  They are not decoded into text.
+package subtitle
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/wunderbarb/timecode"
+)
+
+// Format is a subtitle file format.
+type Format int
+
+const (
+	// SRT is the SubRip format.
+	SRT Format = iota
+	// WebVTT is the Web Video Text Tracks format.
+	WebVTT
+	// SCC is the Scenarist Closed Caption format.  Its timecodes are at 29.97 FPS.
+	SCC
+)
+
+var (
+	// ErrInvalidCue is returned when a cue cannot be parsed.
+	ErrInvalidCue = errors.New("subtitle: invalid cue")
+	// ErrUnsupportedConversion is returned when converting between SCC and a text format.
+	ErrUnsupportedConversion = errors.New("subtitle: unsupported conversion")
+)
+
+const (
+	cArrow      = "-->"
+	cWebVTT     = "WEBVTT"
+	cSCCHeader  = "Scenarist_SCC V1.0"
+	cSCCTCWidth = 11
+)
+
+// Cue is a subtitle cue.
+type Cue struct {
+	// Index is the SRT sequence number.
+	Index int
+	// ID is the WebVTT cue identifier.
+	ID    string
+	Start timecode.Timecode
+	// End is the end of the cue.  It is not defined for SCC.
+	End timecode.Timecode
+	// Settings are the WebVTT cue settings, e.g., `align:start`.
+	Settings string
+	// Text are the lines of text of SRT and WebVTT.
+	Text []string
+	// Data are the CEA-608 byte pairs of SCC, e.g., `9420 9420 94ae`.
+	Data string
+	// Notes are the WebVTT blocks following the cue that are not cues, e.g., NOTE blocks.
+	Notes []string
+}
+
+// File is a subtitle file.
+type File struct {
+	Format Format
+	Rate   timecode.Rate
+	// Header are the blocks preceding the first cue, e.g., the WEBVTT signature or the SCC header.
+	Header []string
+	Cues   []Cue
+}
+
+// Read reads a subtitle file of the given format.  The timings are converted to timecodes at the rate `r`.
+// SCC requires 29.97 FPS.  The drop frame of SCC is given by the separator of each timecode.
+func Read(rd io.Reader, f Format, r timecode.Rate) (*File, error) {
+	if !r.Valid() || (f == SCC && r.FPS != timecode.FPS2997) {
+		return nil, timecode.ErrInvalidFPS
+	}
+	blocks, err := readBlocks(rd)
+	if err != nil {
+		return nil, err
+	}
+	file := &File{Format: f, Rate: r}
+	for _, b := range blocks {
+		if err := file.parseBlock(b); err != nil {
+			return nil, err
+		}
+	}
+	return file, nil
+}
+
+// Write writes the subtitle file in its format.
+func (f *File) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	var blocks []string
+	if len(f.Header) != 0 {
+		blocks = append(blocks, f.Header...)
+	} else {
+		switch f.Format {
+		case WebVTT:
+			blocks = append(blocks, cWebVTT)
+		case SCC:
+			blocks = append(blocks, cSCCHeader)
+		case SRT:
+		}
+	}
+	for i := range f.Cues {
+		blocks = append(blocks, f.formatCue(&f.Cues[i]))
+		blocks = append(blocks, f.Cues[i].Notes...)
+	}
+	for _, b := range blocks {
+		if _, err := bw.WriteString(b + "\n\n"); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// String returns the file as written by Write.
+func (f *File) String() string {
+	var sb strings.Builder
+	_ = f.Write(&sb)
+	return sb.String()
+}
+
+// Add retimes all the cues by adding the offset `offset`.  The offset must have the rate of the file.
+func (f *File) Add(offset timecode.Timecode) error {
+	return f.each(func(tc *timecode.Timecode) error { return tc.Add(offset) })
+}
+
+// Subtract retimes all the cues by subtracting the offset `offset`.  The offset must have the rate of
+// the file.
+func (f *File) Subtract(offset timecode.Timecode) error {
+	return f.each(func(tc *timecode.Timecode) error { return tc.Subtract(offset) })
+}
+
+// ConvertRate converts all the cues to the rate `r` preserving their time.
+func (f *File) ConvertRate(r timecode.Rate) error {
+	if !r.Valid() || (f.Format == SCC && r.FPS != timecode.FPS2997) {
+		return timecode.ErrInvalidFPS
+	}
+	old := f.Rate
+	f.Rate = r
+	return f.each(func(tc *timecode.Timecode) error {
+		if tc.Rate() != old && f.Format != SCC {
+			return timecode.ErrInconsistentFPS
+		}
+		n, err := timecode.NewFromRate(r, int(math.Round(float64(tc.Frame())*r.FPS/tc.Rate().FPS)))
+		if err != nil {
+			return err
+		}
+		*tc = *n
+		return nil
+	})
+}
+
+// Convert converts the file between SRT and WebVTT.  The conversions from and to SCC are not supported.
+func (f *File) Convert(format Format) error {
+	if f.Format == format {
+		return nil
+	}
+	if f.Format == SCC || format == SCC {
+		return ErrUnsupportedConversion
+	}
+	f.Format = format
+	f.Header = nil
+	for i := range f.Cues {
+		c := &f.Cues[i]
+		c.Index = i + 1
+		c.ID = ""
+		c.Settings = ""
+		c.Notes = nil
+	}
+	return nil
+}
+
+// each applies `fn` to the start and end of each cue.
+func (f *File) each(fn func(tc *timecode.Timecode) error) error {
+	for i := range f.Cues {
+		c := &f.Cues[i]
+		if err := fn(&c.Start); err != nil {
+			return err
+		}
+		if f.Format == SCC {
+			continue
+		}
+		if err := fn(&c.End); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *File) parseBlock(b []string) error {
+	switch f.Format {
+	case SRT:
+		return f.parseSRT(b)
+	case WebVTT:
+		return f.parseWebVTT(b)
+	case SCC:
+		return f.parseSCC(b)
+	default:
+		return ErrInvalidCue
+	}
+}
+
+func (f *File) parseSRT(b []string) error {
+	const cMinLines = 2
+	if len(b) < cMinLines {
+		return errors.Wrapf(ErrInvalidCue, "%q", b[0])
+	}
+	idx, err := strconv.Atoi(strings.TrimSpace(b[0]))
+	if err != nil {
+		return errors.Wrapf(ErrInvalidCue, "%q", b[0])
+	}
+	c := Cue{Index: idx, Text: b[2:]}
+	if _, err := f.parseTiming(&c, b[1], timecode.NewFromSRT); err != nil {
+		return err
+	}
+	f.Cues = append(f.Cues, c)
+	return nil
+}
+
+func (f *File) parseWebVTT(b []string) error {
+	timing := -1
+	for i, l := range b[:min(2, len(b))] {
+		if strings.Contains(l, cArrow) {
+			timing = i
+			break
+		}
+	}
+	if timing < 0 {
+		// Signature, NOTE, STYLE or REGION blocks.
+		block := strings.Join(b, "\n")
+		if len(f.Cues) == 0 {
+			f.Header = append(f.Header, block)
+		} else {
+			last := &f.Cues[len(f.Cues)-1]
+			last.Notes = append(last.Notes, block)
+		}
+		return nil
+	}
+	c := Cue{Text: b[timing+1:]}
+	if timing == 1 {
+		c.ID = b[0]
+	}
+	settings, err := f.parseTiming(&c, b[timing], timecode.NewFromWebVTT)
+	if err != nil {
+		return err
+	}
+	c.Settings = settings
+	f.Cues = append(f.Cues, c)
+	return nil
+}
+
+func (f *File) parseSCC(b []string) error {
+	for _, l := range b {
+		if l == cSCCHeader {
+			f.Header = append(f.Header, l)
+			continue
+		}
+		if len(l) < cSCCTCWidth {
+			return errors.Wrapf(ErrInvalidCue, "%q", l)
+		}
+		ts := l[:cSCCTCWidth]
+		var tc *timecode.Timecode
+		var err error
+		if strings.Contains(ts, ";") {
+			tc, err = timecode.NewWithDropFrameFromString(ts)
+		} else {
+			tc, err = timecode.NewFromString(timecode.FPS2997, ts)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "subtitle: %q", l)
+		}
+		f.Cues = append(f.Cues, Cue{Start: *tc, Data: strings.TrimSpace(l[cSCCTCWidth:])})
+	}
+	return nil
+}
+
+// parseTiming parses the timing line `start --> end [settings]` and returns the settings.
+func (f *File) parseTiming(c *Cue, line string,
+	parse func(float64, string) (*timecode.Timecode, error)) (string, error) {
+	const cMinFields = 3
+	fields := strings.Fields(line)
+	if len(fields) < cMinFields || fields[1] != cArrow {
+		return "", errors.Wrapf(ErrInvalidCue, "%q", line)
+	}
+	dst := []*timecode.Timecode{&c.Start, &c.End}
+	for i, s := range []string{fields[0], fields[2]} {
+		tc, err := parse(f.Rate.FPS, s)
+		if err != nil {
+			return "", errors.Wrapf(err, "subtitle: %q", line)
+		}
+		tc, err = timecode.NewFromRate(f.Rate, tc.Frame())
+		if err != nil {
+			return "", err
+		}
+		*dst[i] = *tc
+	}
+	return strings.Join(fields[cMinFields:], " "), nil
+}
+
+// formatCue returns the cue in the format of the file.
+func (f *File) formatCue(c *Cue) string {
+	var lines []string
+	switch f.Format {
+	case SRT:
+		lines = append(lines, strconv.Itoa(c.Index), c.Start.AsSRT()+" "+cArrow+" "+c.End.AsSRT())
+	case WebVTT:
+		if c.ID != "" {
+			lines = append(lines, c.ID)
+		}
+		timing := c.Start.AsWebVTT() + " " + cArrow + " " + c.End.AsWebVTT()
+		if c.Settings != "" {
+			timing += " " + c.Settings
+		}
+		lines = append(lines, timing)
+	case SCC:
+		return fmt.Sprintf("%s\t%s", c.Start.String(), c.Data)
+	}
+	return strings.Join(append(lines, c.Text...), "\n")
+}
+
+// readBlocks returns the blocks of lines separated by blank lines.
+func readBlocks(rd io.Reader) ([][]string, error) {
+	var blocks [][]string
+	var block []string
+	sc := bufio.NewScanner(rd)
+	first := true
+	for sc.Scan() {
+		l := sc.Text()
+		if first {
+			l = strings.TrimPrefix(l, "\ufeff")
+			first = false
+		}
+		if strings.TrimSpace(l) == "" {
+			if len(block) != 0 {
+				blocks = append(blocks, block)
+			}
+			block = nil
+			continue
+		}
+		block = append(block, l)
+	}
+	if len(block) != 0 {
+		blocks = append(blocks, block)
+	}
+	return blocks, sc.Err()
+}
@@ -0,0 +1,134 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package subtitle
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wunderbarb/timecode"
+)
+
+var testCounter int
+
+const (
+	cSRT = "1\n00:00:01,000 --> 00:00:02,480\nHello\nworld\n\n" +
+		"2\n00:01:02,360 --> 00:01:04,000\n<i>Bye</i>\n\n"
+	cVTT = "WEBVTT\n\nNOTE produced by hand\n\n" +
+		"intro\n00:00:01.000 --> 00:00:02.480 align:start\nHello\nworld\n\n" +
+		"00:01:02.360 --> 00:01:04.000\n<i>Bye</i>\n\n" +
+		"NOTE trailing\n\n"
+	cSCC = "Scenarist_SCC V1.0\n\n" +
+		"00:00:00;22\t9420 9420 94ae 94ae 9452 9452 97a2 97a2\n\n" +
+		"00:00:02;15\t942c 942c\n\n"
+)
+
+func TestRead(t *testing.T) {
+	require, assert := Describe(t)
+
+	f, err := Read(strings.NewReader(cSRT), SRT, timecode.Rate25)
+	require.NoError(err)
+	require.Len(f.Cues, 2)
+	assert.Equal(1, f.Cues[0].Index)
+	assert.Equal(25, f.Cues[0].Start.Frame())
+	assert.Equal(62, f.Cues[0].End.Frame())
+	assert.Equal([]string{"Hello", "world"}, f.Cues[0].Text)
+	assert.Equal(cSRT, f.String())
+
+	f, err = Read(strings.NewReader("\ufeff"+cVTT), WebVTT, timecode.Rate25)
+	require.NoError(err)
+	require.Len(f.Cues, 2)
+	assert.Equal([]string{"WEBVTT", "NOTE produced by hand"}, f.Header)
+	assert.Equal("intro", f.Cues[0].ID)
+	assert.Equal("align:start", f.Cues[0].Settings)
+	assert.Equal([]string{"NOTE trailing"}, f.Cues[1].Notes)
+	assert.Equal(cVTT, f.String())
+
+	f, err = Read(strings.NewReader(cSCC), SCC, timecode.Rate2997DF)
+	require.NoError(err)
+	require.Len(f.Cues, 2)
+	assert.Equal(22, f.Cues[0].Start.Frame())
+	assert.Equal("942c 942c", f.Cues[1].Data)
+	assert.Equal(cSCC, f.String())
+
+	_, err = Read(strings.NewReader(cSCC), SCC, timecode.Rate25)
+	assert.ErrorIs(err, timecode.ErrInvalidFPS)
+	_, err = Read(strings.NewReader("1\n00:00:01.000 --> 00:00:02.000\nHello\n"), SRT, timecode.Rate25)
+	assert.ErrorIs(err, timecode.ErrInvalidTimeCode)
+	_, err = Read(strings.NewReader("one\n00:00:01,000 --> 00:00:02,000\nHello\n"), SRT, timecode.Rate25)
+	assert.ErrorIs(err, ErrInvalidCue)
+}
+
+func TestFile_Add(t *testing.T) {
+	require, assert := Describe(t)
+
+	f, err := Read(strings.NewReader(cSRT), SRT, timecode.Rate25)
+	require.NoError(err)
+	offset, _ := timecode.NewFromString(25, "00:00:10:00")
+	require.NoError(f.Add(*offset))
+	assert.Equal("00:00:11,000", f.Cues[0].Start.AsSRT())
+	assert.Equal("00:01:14,000", f.Cues[1].End.AsSRT())
+	require.NoError(f.Subtract(*offset))
+	assert.Equal(cSRT, f.String())
+	bad, _ := timecode.NewFromString(24, "00:00:10:00")
+	assert.ErrorIs(f.Add(*bad), timecode.ErrInconsistentFPS)
+
+	f, err = Read(strings.NewReader(cSCC), SCC, timecode.Rate2997DF)
+	require.NoError(err)
+	offset, _ = timecode.NewWithDropFrameFromString("00:59:59;00")
+	require.NoError(f.Add(*offset))
+	assert.Equal("00:59:59;22", f.Cues[0].Start.String())
+	assert.Equal("01:00:01;15", f.Cues[1].Start.String())
+}
+
+func TestFile_ConvertRate(t *testing.T) {
+	require, assert := Describe(t)
+
+	f, err := Read(strings.NewReader(cSRT), SRT, timecode.Rate25)
+	require.NoError(err)
+	require.NoError(f.ConvertRate(timecode.Rate24))
+	assert.Equal(timecode.Rate24, f.Rate)
+	assert.Equal(24, f.Cues[0].Start.Frame())
+	assert.Equal("00:00:01,000", f.Cues[0].Start.AsSRT())
+	assert.Equal("00:01:04,000", f.Cues[1].End.AsSRT())
+
+	f, err = Read(strings.NewReader(cSCC), SCC, timecode.Rate2997DF)
+	require.NoError(err)
+	assert.ErrorIs(f.ConvertRate(timecode.Rate25), timecode.ErrInvalidFPS)
+	require.NoError(f.ConvertRate(timecode.Rate2997))
+	assert.Equal("00:00:02:15", f.Cues[1].Start.String())
+}
+
+func TestFile_Convert(t *testing.T) {
+	require, assert := Describe(t)
+
+	f, err := Read(strings.NewReader(cVTT), WebVTT, timecode.Rate25)
+	require.NoError(err)
+	require.NoError(f.Convert(SRT))
+	assert.Equal(cSRT, f.String())
+	require.NoError(f.Convert(WebVTT))
+	assert.True(strings.HasPrefix(f.String(), "WEBVTT\n\n00:00:01.000 --> 00:00:02.480\nHello"))
+	assert.ErrorIs(f.Convert(SCC), ErrUnsupportedConversion)
+}
+
+// Describe displays the rank of the test, the name of the function
+// and its optional description provided by 'msg'.  It initializes an assert
+// and a require function and returns them.
+func Describe(t *testing.T, msg ...string) (*require.Assertions,
+	*assert.Assertions) {
+
+	dispMsg := ""
+	if len(msg) != 0 {
+		dispMsg = msg[0]
+	}
+	name := strings.TrimPrefix(strings.TrimPrefix(t.Name(), "Test"), "_")
+	fmt.Printf("Test %d: %s %s\n", testCounter, name, dispMsg)
+	testCounter++
+	return require.New(t), assert.New(t)
+}
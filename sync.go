@@ -0,0 +1,216 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// cSyncWindow is the default number of observations used by the drift estimator.
+	cSyncWindow = 32
+	// cSyncJitter is the default jump threshold in frames.  It absorbs the quantization of the timecode.
+	cSyncJitter = 2
+	cPPM        = 1e6
+	cSecPerDay  = cModulo24H
+)
+
+// Jump describes a discontinuity detected by a Synchronizer.
+type Jump struct {
+	// Index is the rank of the observation that caused the jump.  The first observation is 0.
+	Index int
+	// Timecode is the observed timecode.
+	Timecode string
+	// Reference is the reference timestamp of the observation.
+	Reference time.Duration
+	// Delta is the difference between the observed offset and the predicted one.
+	Delta time.Duration
+}
+
+// SyncEstimate is the current estimate of a Synchronizer.
+type SyncEstimate struct {
+	// Offset is the offset of the observed timecode relative to the reference at the latest observation.
+	// A positive offset means that the observed timecode is ahead of the reference.
+	Offset time.Duration
+	// DriftPPM is the drift of the observed timecode relative to the reference in parts per million.
+	// A positive drift means that the observed source runs faster than the reference.
+	DriftPPM float64
+	// Samples is the number of observations used for the estimation.
+	Samples int
+}
+
+// Synchronizer tracks the offset and the drift of a timecode source, e.g., a camera, against a reference
+// clock, e.g., LTC or time of day.  It is fed with pairs of observed timecode and reference timestamp.
+//
+// The drift is estimated with a Theil-Sen estimator over a sliding window, i.e., the median of the
+// pairwise slopes, which is robust to jitter and isolated outliers.  When an observation deviates from
+// the predicted offset by more than the threshold, it is reported as a jump and the estimator is
+// re-jammed on this observation.
+//
+// Midnight rollovers of both the timecode and the reference are unwrapped.
+// A Synchronizer is not safe for concurrent use.
+type Synchronizer struct {
+	window    int
+	threshold time.Duration
+	fps       float64
+	dropFrame bool
+	started   bool
+	count     int
+	obs       []float64
+	ref       []float64
+	lastObs   float64
+	lastRef   float64
+	slope     float64
+	intercept float64
+	jumps     []Jump
+}
+
+// NewSynchronizer returns a Synchronizer using the last `window` observations to estimate the drift.
+// An observation deviating by more than `threshold` from the prediction is a jump.  If `window` is lower
+// than 2, a default window of 32 observations is used.  If `threshold` is not positive, the threshold
+// is the duration of two frames.
+func NewSynchronizer(window int, threshold time.Duration) *Synchronizer {
+	if window < 2 {
+		window = cSyncWindow
+	}
+	return &Synchronizer{window: window, threshold: threshold}
+}
+
+// Observe adds the observed timecode `tc` captured at the reference timestamp `ref`.  `ref` is the
+// elapsed time since the reference's midnight.  It returns true if the observation is a jump.
+// All the observed timecodes must have the same frame rate and drop frame.
+func (s *Synchronizer) Observe(tc Timecode, ref time.Duration) (bool, error) {
+	if !s.started {
+		s.fps = tc.fps
+		s.dropFrame = tc.dropFrame
+		if s.threshold <= 0 {
+			s.threshold = time.Duration(cSyncJitter * float64(time.Second) / tc.fps)
+		}
+		s.started = true
+		s.lastObs = tc.seconds()
+		s.lastRef = ref.Seconds()
+		s.add(s.lastObs, s.lastRef)
+		return false, nil
+	}
+	if tc.fps != s.fps || tc.dropFrame != s.dropFrame {
+		return false, ErrInconsistentFPS
+	}
+	o := unwrapDay(tc.seconds(), s.lastObs)
+	r := unwrapDay(ref.Seconds(), s.lastRef)
+	s.lastObs, s.lastRef = o, r
+	if len(s.obs) >= 2 {
+		delta := (o - r) - s.predict(r)
+		if math.Abs(delta) > s.threshold.Seconds() {
+			s.jumps = append(s.jumps, Jump{Index: s.count, Timecode: tc.String(), Reference: ref,
+				Delta: seconds2Duration(delta)})
+			s.obs, s.ref = s.obs[:0], s.ref[:0]
+			s.add(o, r)
+			return true, nil
+		}
+	}
+	s.add(o, r)
+	return false, nil
+}
+
+// Estimate returns the current estimate of the offset and drift.
+func (s *Synchronizer) Estimate() SyncEstimate {
+	if len(s.obs) == 0 {
+		return SyncEstimate{}
+	}
+	return SyncEstimate{
+		Offset:   seconds2Duration(s.predict(s.lastRef)),
+		DriftPPM: s.slope * cPPM,
+		Samples:  len(s.obs),
+	}
+}
+
+// Jumps returns the jumps detected so far.
+func (s *Synchronizer) Jumps() []Jump {
+	return append([]Jump(nil), s.jumps...)
+}
+
+// Correct returns the timecode that the observed timecode `tc` should have according to the reference.
+// The returned timecode has the same frame rate and drop frame as `tc`.
+func (s *Synchronizer) Correct(tc Timecode) (*Timecode, error) {
+	if !s.started {
+		return Clone(&tc), nil
+	}
+	if tc.fps != s.fps || tc.dropFrame != s.dropFrame {
+		return nil, ErrInconsistentFPS
+	}
+	o := unwrapDay(tc.seconds(), s.lastObs)
+	r := (o - s.intercept) / (1 + s.slope)
+	r = math.Mod(r, cSecPerDay)
+	if r < 0 {
+		r += cSecPerDay
+	}
+	return &Timecode{fps: tc.fps, dropFrame: tc.dropFrame, currentFrame: cast2Round(r * tc.fps)}, nil
+}
+
+// add appends the observation to the window and updates the estimation.
+func (s *Synchronizer) add(o float64, r float64) {
+	s.count++
+	s.obs = append(s.obs, o)
+	s.ref = append(s.ref, r)
+	if len(s.obs) > s.window {
+		s.obs = s.obs[1:]
+		s.ref = s.ref[1:]
+	}
+	s.estimate()
+}
+
+// estimate computes the Theil-Sen estimation of the offset as a linear function of the reference.
+func (s *Synchronizer) estimate() {
+	n := len(s.obs)
+	var slopes []float64
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			dr := s.ref[j] - s.ref[i]
+			if dr == 0 {
+				continue
+			}
+			slopes = append(slopes, ((s.obs[j]-s.ref[j])-(s.obs[i]-s.ref[i]))/dr)
+		}
+	}
+	s.slope = 0
+	if len(slopes) != 0 {
+		s.slope = median(slopes)
+	}
+	intercepts := make([]float64, n)
+	for i := range intercepts {
+		intercepts[i] = (s.obs[i] - s.ref[i]) - s.slope*s.ref[i]
+	}
+	s.intercept = median(intercepts)
+}
+
+// predict returns the predicted offset, in seconds, at the reference `r`.
+func (s *Synchronizer) predict(r float64) float64 {
+	return s.intercept + s.slope*r
+}
+
+// seconds returns the elapsed time, in seconds, at the beginning of the frame.
+func (t *Timecode) seconds() float64 {
+	return float64(t.currentFrame) / t.fps
+}
+
+// unwrapDay returns the value `v` shifted by a multiple of 24 hours so that it is the closest to `prev`.
+func unwrapDay(v float64, prev float64) float64 {
+	return v + cSecPerDay*math.Round((prev-v)/cSecPerDay)
+}
+
+func seconds2Duration(s float64) time.Duration {
+	return time.Duration(math.Round(s * float64(time.Second)))
+}
+
+func median(v []float64) float64 {
+	sort.Float64s(v)
+	n := len(v)
+	if n%2 == 1 {
+		return v[n/2]
+	}
+	return (v[n/2-1] + v[n/2]) / 2 //nolint:gomnd
+}
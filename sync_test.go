@@ -0,0 +1,99 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// synthetic returns the timecode observed at the reference `ref` by a source running with
+// the drift `ppm` and the offset `offset`.
+func synthetic(fps float64, ref time.Duration, offset time.Duration, ppm float64) Timecode {
+	s := ref.Seconds()*(1+ppm/cPPM) + offset.Seconds()
+	tc, _ := NewFromFrame(fps, int(math.Floor(s*fps)))
+	return *tc
+}
+
+func TestSynchronizer_Observe(t *testing.T) {
+	require, assert := Describe(t)
+
+	tests := []struct {
+		fps    float64
+		offset time.Duration
+		ppm    float64
+	}{
+		{cFPS25, 2 * time.Second, 100},
+		{cFPS24, -3 * time.Second, -40},
+		{FPS2997, 0, 20},
+	}
+	for i, tt := range tests {
+		s := NewSynchronizer(120, 0)
+		start := 10 * time.Hour
+		for j := 0; j < 120; j++ {
+			ref := start + time.Duration(j)*time.Minute
+			jump, err := s.Observe(synthetic(tt.fps, ref, tt.offset, tt.ppm), ref)
+			require.NoError(err)
+			assert.False(jump, "sample %d", i+1)
+		}
+		e := s.Estimate()
+		assert.Equal(120, e.Samples)
+		assert.InDelta(tt.ppm, e.DriftPPM, 2, "sample %d", i+1)
+		end := start + 119*time.Minute
+		expOffset := tt.offset + time.Duration(end.Seconds()*tt.ppm/cPPM*float64(time.Second))
+		assert.InDelta(expOffset.Seconds(), e.Offset.Seconds(), 1/tt.fps, "sample %d", i+1)
+
+		tc := synthetic(tt.fps, end, tt.offset, tt.ppm)
+		c, err := s.Correct(tc)
+		require.NoError(err)
+		assert.InDelta(end.Seconds()*tt.fps, float64(c.Frame()), 1, "sample %d", i+1)
+		assert.Empty(s.Jumps())
+	}
+}
+
+func TestSynchronizer_Jumps(t *testing.T) {
+	require, assert := Describe(t)
+
+	s := NewSynchronizer(16, 0)
+	for j := 0; j < 40; j++ {
+		ref := time.Duration(j) * time.Second
+		offset := time.Second
+		if j >= 20 {
+			offset = 5 * time.Second
+		}
+		jump, err := s.Observe(synthetic(cFPS25, ref, offset, 0), ref)
+		require.NoError(err)
+		assert.Equal(j == 20, jump, "observation %d", j)
+	}
+	jumps := s.Jumps()
+	require.Len(jumps, 1)
+	assert.Equal(20, jumps[0].Index)
+	assert.Equal("00:00:25:00", jumps[0].Timecode)
+	assert.Equal(4*time.Second, jumps[0].Delta)
+	assert.Equal(5*time.Second, s.Estimate().Offset)
+
+	t3, _ := NewWithDropFrame(0)
+	_, err := s.Observe(*t3, 0)
+	assert.ErrorIs(err, ErrInconsistentFPS)
+	_, err = s.Correct(*t3)
+	assert.ErrorIs(err, ErrInconsistentFPS)
+}
+
+func TestSynchronizer_Midnight(t *testing.T) {
+	require, assert := Describe(t)
+
+	s := NewSynchronizer(0, 0)
+	start := 24*time.Hour - 30*time.Second
+	for j := 0; j < 60; j++ {
+		ref := start + time.Duration(j)*time.Second
+		tc := synthetic(cFPS25, ref%(24*time.Hour), 2*time.Second, 0)
+		tc.SetFrame(tc.Frame() % (cModulo24H * cFPS25))
+		jump, err := s.Observe(tc, ref%(24*time.Hour))
+		require.NoError(err)
+		assert.False(jump, "observation %d", j)
+	}
+	assert.Equal(2*time.Second, s.Estimate().Offset)
+}
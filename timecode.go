@@ -10,12 +10,15 @@ package timecode
 import (
 	"fmt"
 	"math"
+	"math/big"
+	"math/bits"
 	"math/rand"
 	"regexp"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
-	"github.com/shopspring/decimal"
 )
 
 const (
@@ -25,6 +28,8 @@ const (
 	FPS23976fps = 24000.0 / 1001.0
 
 	cPrecision = 1000
+	cMicro     = 1000 * cPrecision
+	cNano      = 1000 * cMicro
 	cModulo24H = 24 * cNumSec * cNumSec
 	cNumSec    = 60
 )
@@ -37,7 +42,10 @@ var (
 	// ErrInvalidTimeCode is returned when the parsed timecode is not valid.
 	ErrInvalidTimeCode = errors.New("invalid timecode")
 
-	_rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	_rng   = rand.New(rand.NewSource(time.Now().UnixNano()))
+	_rngMu sync.Mutex
+
+	_reTimecode = regexp.MustCompile(`^\d{2}:[0-5]\d:[0-5]\d[:;][0-2]\d$`)
 )
 
 // Timecode is a structure to handle video timecode as defined by SMPTE.
@@ -56,10 +64,17 @@ func New(fps float64, seconds float64) (*Timecode, error) {
 	if seconds == 0.0 {
 		return &Timecode{fps: fps, currentFrame: 0}, nil
 	}
-	s := decimal.NewFromFloat(seconds)
-	f := decimal.NewFromFloat(fps)
+	ns := math.Round(seconds * cNano)
+	if !(ns < math.MaxUint64) {
+		return nil, ErrInvalidFPS
+	}
+	num, den := ratio(fps)
+	frame, ok := mulDiv(uint64(ns), num, den*cNano)
+	if !ok || frame > math.MaxInt {
+		return nil, ErrInvalidFPS
+	}
 	return &Timecode{fps: fps,
-		currentFrame: int(s.Mul(f).IntPart())}, nil
+		currentFrame: int(frame)}, nil
 }
 
 // NewFromFrame initializes a Timecode structure with the given fps and frame.  The first frame
@@ -114,7 +129,7 @@ func (t *Timecode) Add(ta Timecode) error {
 	if !t.sameFrameRate(ta) {
 		return ErrInconsistentFPS
 	}
-	modulo := cast2Int(cModulo24H * t.fps)
+	modulo := t.modulo24H()
 	t.currentFrame += ta.currentFrame
 	if t.currentFrame >= modulo {
 		t.currentFrame -= modulo
@@ -122,6 +137,12 @@ func (t *Timecode) Add(ta Timecode) error {
 	return nil
 }
 
+// After returns true if the timecode `t` is strictly after the given timecode `ta`.  It panics if
+// their frame rate or drop frame differ.
+func (t *Timecode) After(ta Timecode) bool {
+	return Compare(*t, ta) > 0
+}
+
 // AtOffsetFrom returns true if the timecode `t` is at offset `o` from the given timecode `ta`.
 //
 // The timecodes have to be with the same frame rate and drop frame.
@@ -132,9 +153,10 @@ func (t *Timecode) AtOffsetFrom(ta Timecode, o int) bool {
 	return ta.currentFrame+o == t.currentFrame
 }
 
-// Before returns true if the timecode `t` is before (or equal to) the given timecode `ta`.
+// Before returns true if the timecode `t` is strictly before the given timecode `ta`.  It panics if
+// their frame rate or drop frame differ.
 func (t *Timecode) Before(ta Timecode) bool {
-	return t.currentFrame <= ta.currentFrame
+	return Compare(*t, ta) < 0
 }
 
 // Clone returns a clone of the timecode.
@@ -167,7 +189,7 @@ func (t *Timecode) Offset(fra int) {
 // AsMilliseconds returns the timecode as a properly formatted string. HH:MM:SS.ms
 func (t *Timecode) AsMilliseconds() string {
 	h1, m1, s1, ms := t.parse()
-	return fmt.Sprintf("%02d:%02d:%02d.%03d", h1, m1, s1, cast2Int(cPrecision*ms))
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", h1, m1, s1, ms)
 }
 
 // Convert method Converts from one timecode to another without changing the frame rate.
@@ -186,59 +208,79 @@ func (t *Timecode) Frames() int {
 }
 
 // Milliseconds method returns the number of milliseconds in the timecode at the beginning of the frame.
+// It is rounded to the microsecond before truncation.
 func (t *Timecode) Milliseconds() int {
-	s := decimal.NewFromInt(int64(t.currentFrame)).Mul(decimal.NewFromFloat(cPrecision))
-	a := s.DivRound(decimal.NewFromFloat(t.fps), 3)
-	return int(a.IntPart())
+	if t.currentFrame < 0 {
+		return -(&Timecode{fps: t.fps, currentFrame: -t.currentFrame}).Milliseconds()
+	}
+	// Rounding half up to the microsecond before truncation.
+	return t.milliseconds(1)
 }
 
 // Parse parses the given timecode string and sets the timecode accordingly.  The timecode must be in the format
 // HH:MM:SS:fr or HH:MM:SS;ff. The frame `ff` must comply with the frame rate and drop frame of the timecode.
+// The returned error is a *ParseError.
 func (t *Timecode) Parse(ts string) error {
-	const (
-		cDlm1 = 2
-		cDlm2 = 5
-		cDlm3 = 8
-	)
-	if !regexp.MustCompile(`^\d{2}:[0-5]\d:[0-5]\d[:;][0-2]\d$`).MatchString(ts) {
-		return ErrInvalidTimeCode
-	}
-	tsa := []rune(ts)
-	h1 := extractHour(tsa[0], tsa[1])
-	m1 := extractMin(tsa[cDlm1+1], tsa[cDlm1+2])
-	s1 := extractMin(tsa[cDlm2+1], tsa[cDlm2+2])
-	f := extractMin(tsa[cDlm3+1], tsa[cDlm3+2])
-	if h1 == 0 && m1 == 0 && s1 == 0 && f == 0 {
-		t.currentFrame = 0
-		return nil
-	}
-	fr := cast2Round(t.fps)
-	if f >= fr {
-		return ErrInconsistentFPS
-	}
-	if !t.dropFrame {
-		// We are placing our self at slightly after.  This allows us to avoid rounding issues.
-		t.currentFrame = (cNumSec*cNumSec*h1+m1*cNumSec+s1)*fr + f
-		return nil
+	f, err := parseTimecode(t.table(), ts)
+	if err != nil {
+		return err
 	}
-	if tsa[cDlm3] != ';' {
-		return ErrInvalidTimeCode
+	t.currentFrame = f
+	return nil
+}
+
+// ParseBytes is like Parse but parses a byte slice.  It does not allocate unless it fails.
+func (t *Timecode) ParseBytes(b []byte) error {
+	f, err := parseTimecode(t.table(), b)
+	if err != nil {
+		return err
 	}
+	t.currentFrame = f
+	return nil
+}
 
-	if s1 == 0 && (f == 0 || f == 1) {
-		switch m1 {
-		case 0, 10, 20, 30, 40, 50:
-		default:
-			return ErrInvalidTimeCode
+// AppendFormat appends the timecode formatted as HH:MM:SS:ff or HH:MM:SS;ff to `dst` and returns the
+// extended buffer.  It does not allocate if `dst` has enough capacity.
+func (t *Timecode) AppendFormat(dst []byte) []byte {
+	return t.table().appendFormat(dst, t.currentFrame)
+}
+
+// parseTimecode returns the frame of the timecode string `ts`.  It implements Parse and ParseBytes.
+func parseTimecode[T string | []byte](ft frameTable, ts T) (int, error) {
+	const (
+		cDlm1      = 2
+		cDlm2      = 5
+		cDlm3      = 8
+		cLen       = 11
+		cMaxMinSec = 59
+	)
+	for i := 0; i < cLen; i++ {
+		if err := checkChar(ts, i); err != nil {
+			return 0, err
 		}
 	}
-	// See https://www.davidheidelberger.com/2010/06/10/drop-frame-timecode/
-	timeBase := int(math.RoundToEven(t.fps))
-	cMinFrames := timeBase * cNumSec
-	cHourFrames := cNumSec * cMinFrames
-	totalMinutes := h1*cNumSec + m1
-	t.currentFrame = h1*cHourFrames + m1*cMinFrames + s1*timeBase + f - 2*(totalMinutes-(totalMinutes/10))
-	return nil
+	if len(ts) > cLen {
+		return 0, newParseError(string(ts), FieldFrames, cLen, ReasonSyntax)
+	}
+	h1 := extractHour(rune(ts[0]), rune(ts[1]))
+	m1 := extractMin(rune(ts[cDlm1+1]), rune(ts[cDlm1+2]))
+	s1 := extractMin(rune(ts[cDlm2+1]), rune(ts[cDlm2+2]))
+	f := extractMin(rune(ts[cDlm3+1]), rune(ts[cDlm3+2]))
+	switch {
+	case m1 > cMaxMinSec:
+		return 0, newParseError(string(ts), FieldMinutes, cDlm1+1, ReasonOutOfRange)
+	case s1 > cMaxMinSec:
+		return 0, newParseError(string(ts), FieldSeconds, cDlm2+1, ReasonOutOfRange)
+	case h1 == 0 && m1 == 0 && s1 == 0 && f == 0:
+		return 0, nil
+	case f >= ft.timeBase:
+		return 0, newParseError(string(ts), FieldFrames, cDlm3+1, ReasonOutOfRange)
+	case ft.dropFrame && ts[cDlm3] != ';':
+		return 0, newParseError(string(ts), FieldSeparator, cDlm3, ReasonWrongSeparator)
+	case ft.dropFrame && isDropped(m1, s1, f):
+		return 0, newParseError(string(ts), FieldFrames, cDlm3+1, ReasonDroppedLabel)
+	}
+	return ft.frame(h1, m1, s1, f)
 }
 
 // SetFrame sets the timecode to the given frame.  The first frame is frame 0.
@@ -252,37 +294,8 @@ func (t *Timecode) SetFrame(fra int) {
 
 // String returns the timecode as a properly formatted string HH:MM:SS:ff.
 func (t *Timecode) String() string {
-	fra := cast2Round(t.fps)
-	if !t.dropFrame {
-		var cMin = cNumSec * fra
-		var cHour = cNumSec * cMin
-		h1 := t.currentFrame / cHour
-		rem := t.currentFrame % cHour
-		m1 := rem / cMin
-		rem %= cMin
-		s1 := rem / fra
-		fr := t.currentFrame - (h1*cHour + m1*cMin + s1*fra)
-		return fmt.Sprintf("%02d:%02d:%02d:%02d", h1, m1, s1, fr)
-	}
-
-	// See https://www.davidheidelberger.com/2010/06/10/drop-frame-timecode/
-	dropFrames := 2 // round(framerate * .066666);
-	framesPerHour := cast2Round(t.fps * cNumSec * cNumSec)
-	framesPerDay := 24 * framesPerHour
-	framesPer10Min := cast2Round(t.fps * 10 * cNumSec)
-	framesPerMin := cNumSec*cast2Round(t.fps) - dropFrames
-	frameNumber := t.currentFrame % framesPerDay
-	d := frameNumber / framesPer10Min
-	m := frameNumber % framesPer10Min
-	frameNumber += 9 * d * dropFrames
-	if m > dropFrames {
-		frameNumber += dropFrames * ((m - dropFrames) / framesPerMin)
-	}
-	fr := frameNumber % fra
-	s1 := (frameNumber / fra) % cNumSec
-	m1 := ((frameNumber / fra) / cNumSec) % cNumSec
-	h1 := (((frameNumber / fra) / cNumSec) / cNumSec) % 24
-	return fmt.Sprintf("%02d:%02d:%02d;%02d", h1, m1, s1, fr)
+	var buf [11]byte
+	return string(t.AppendFormat(buf[:0]))
 }
 
 // Subtract subtracts the timecode ta to the current timecode.
@@ -294,22 +307,25 @@ func (t *Timecode) Subtract(ta Timecode) error {
 	}
 	t.currentFrame -= ta.currentFrame
 	if t.currentFrame < 0 {
-		modulo := cast2Int(cModulo24H * t.fps)
-		t.currentFrame += modulo
+		t.currentFrame += t.modulo24H()
 	}
 	return nil
 }
 
 // RandomTimecode generates a random timecode with the frame rate `fps` in the range 0 to 12 hours.
+// It is safe for concurrent use.  Use a Generator for reproducible sequences, other bounds or drop frame.
 func RandomTimecode(fps float64) Timecode {
+	_rngMu.Lock()
 	// We use `rand` because weak randomness is not an issue.
-	t, _ := New(fps,
-		float64(_rng.Intn(12*cNumSec*cNumSec*100)/100)) //nolint:gosec
+	n := _rng.Intn(12 * cNumSec * cNumSec * 100) //nolint:gosec
+	_rngMu.Unlock()
+	t, _ := New(fps, float64(n/100))
 	return *t
 }
 
-// parse parses the timecode and returns the hours, minutes, seconds and milliseconds.
-func (t *Timecode) parse() (h1 int, m1 int, s1 int, ms float64) {
+// parse parses the timecode and returns the hours, minutes, seconds and milliseconds.  The milliseconds
+// are rounded to the nearest.
+func (t *Timecode) parse() (h1 int, m1 int, s1 int, ms int) {
 	const (
 		cNumMinute = cNumSec
 		cNumHour   = cNumMinute * cNumSec
@@ -317,21 +333,155 @@ func (t *Timecode) parse() (h1 int, m1 int, s1 int, ms float64) {
 	if t.currentFrame == 0 {
 		return
 	}
-	dur := t.duration()
-	sec := cast2Int(dur)
+	var total int
+	if t.currentFrame < 0 {
+		total = -(&Timecode{fps: t.fps, currentFrame: -t.currentFrame}).milliseconds(cPrecision)
+	} else {
+		total = t.milliseconds(cPrecision)
+	}
+	sec := total / cPrecision
 	h1 = sec / cNumHour
 	m := sec % cNumHour
 	m1 = m / cNumMinute
 	s1 = (sec % cNumHour) % cNumMinute
-	_, ms = math.Modf(dur)
+	ms = total % cPrecision
 	return
 }
 
-func (t *Timecode) duration() float64 {
-	//return float64(t.currentFrame)/t.fps + cOffsetForRoundingIssues
-	f := decimal.NewFromInt(int64(t.currentFrame))
-	a, _ := f.DivRound(decimal.NewFromFloat(t.fps), 3).Float64()
-	return a
+// milliseconds returns the number of milliseconds at the beginning of the frame computed from twice the
+// number of microseconds `us2` as (us2+half)/2000.  `half` is 1 to round to the microsecond before
+// truncation and cPrecision to round to the millisecond.  The frame must not be negative.
+func (t *Timecode) milliseconds(half uint64) int {
+	num, den := ratio(t.fps)
+	if us2, ok := mulDiv(uint64(t.currentFrame), 2*cMicro*den, num); ok && us2 <= math.MaxUint64-half {
+		return int((us2 + half) / (2 * cPrecision))
+	}
+	us2 := new(big.Int).Mul(big.NewInt(int64(t.currentFrame)), new(big.Int).SetUint64(2*cMicro*den))
+	us2.Quo(us2, new(big.Int).SetUint64(num))
+	return int(us2.Add(us2, new(big.Int).SetUint64(half)).Quo(us2, big.NewInt(2*cPrecision)).Int64())
+}
+
+// fields returns the hours, minutes, seconds and frames of the timecode label.
+func (t *Timecode) fields() (h1 int, m1 int, s1 int, fr int) {
+	return t.table().fields(t.currentFrame)
+}
+
+// setFields sets the timecode to the label with the given hours, minutes, seconds and frames.
+func (t *Timecode) setFields(h1 int, m1 int, s1 int, f int) error {
+	fra, err := t.table().frame(h1, m1, s1, f)
+	if err != nil {
+		return err
+	}
+	t.currentFrame = fra
+	return nil
+}
+
+// framesPerDay returns the number of timecode labels in 24 hours.
+func (t *Timecode) framesPerDay() int {
+	return t.table().framesPerDay
+}
+
+// modulo24H returns the number of frames in 24 hours of elapsed time.
+func (t *Timecode) modulo24H() int {
+	num, den := ratio(t.fps)
+	frames, _ := mulDiv(cModulo24H, num, den)
+	return int(frames)
+}
+
+// table returns the frame table of the frame rate of the timecode.
+func (t *Timecode) table() frameTable {
+	return newFrameTable(Rate{FPS: t.fps, DropFrame: t.dropFrame})
+}
+
+// frameTable holds the divisors that convert frames to timecode labels and back at a given rate.
+// Computing it once amortizes the frame rate setup over many conversions.
+type frameTable struct {
+	timeBase       int
+	framesPer10Min int
+	framesPerDay   int
+	dropFrame      bool
+}
+
+func newFrameTable(r Rate) frameTable {
+	num, den := ratio(r.FPS)
+	ft := frameTable{
+		timeBase:       framesIn(num, den, 1),
+		framesPer10Min: framesIn(num, den, 10*cNumSec),
+		dropFrame:      r.DropFrame,
+	}
+	ft.framesPerDay = cModulo24H * ft.timeBase
+	if r.DropFrame {
+		ft.framesPerDay = 24 * framesIn(num, den, cNumSec*cNumSec)
+	}
+	return ft
+}
+
+// fields returns the hours, minutes, seconds and frames of the label of the frame `frame`.
+func (ft frameTable) fields(frame int) (h1 int, m1 int, s1 int, fr int) {
+	fra := ft.timeBase
+	if !ft.dropFrame {
+		var cMin = cNumSec * fra
+		var cHour = cNumSec * cMin
+		h1 = frame / cHour
+		rem := frame % cHour
+		m1 = rem / cMin
+		rem %= cMin
+		s1 = rem / fra
+		fr = frame - (h1*cHour + m1*cMin + s1*fra)
+		return
+	}
+
+	// See https://www.davidheidelberger.com/2010/06/10/drop-frame-timecode/
+	dropFrames := 2 // round(framerate * .066666);
+	framesPerMin := cNumSec*fra - dropFrames
+	frameNumber := frame % ft.framesPerDay
+	d := frameNumber / ft.framesPer10Min
+	m := frameNumber % ft.framesPer10Min
+	frameNumber += 9 * d * dropFrames
+	if m > dropFrames {
+		frameNumber += dropFrames * ((m - dropFrames) / framesPerMin)
+	}
+	fr = frameNumber % fra
+	s1 = (frameNumber / fra) % cNumSec
+	m1 = ((frameNumber / fra) / cNumSec) % cNumSec
+	h1 = (((frameNumber / fra) / cNumSec) / cNumSec) % 24
+	return
+}
+
+// frame returns the frame of the label with the given hours, minutes, seconds and frames.
+func (ft frameTable) frame(h1 int, m1 int, s1 int, f int) (int, error) {
+	fr := ft.timeBase
+	if f >= fr {
+		return 0, ErrInconsistentFPS
+	}
+	if !ft.dropFrame {
+		// We are placing our self at slightly after.  This allows us to avoid rounding issues.
+		return (cNumSec*cNumSec*h1+m1*cNumSec+s1)*fr + f, nil
+	}
+	if isDropped(m1, s1, f) {
+		return 0, ErrInvalidTimeCode
+	}
+	// See https://www.davidheidelberger.com/2010/06/10/drop-frame-timecode/
+	cMinFrames := fr * cNumSec
+	cHourFrames := cNumSec * cMinFrames
+	totalMinutes := h1*cNumSec + m1
+	return h1*cHourFrames + m1*cMinFrames + s1*fr + f - 2*(totalMinutes-(totalMinutes/10)), nil
+}
+
+// appendFormat appends the label of the frame `frame` formatted as HH:MM:SS:ff or HH:MM:SS;ff to `dst`.
+func (ft frameTable) appendFormat(dst []byte, frame int) []byte {
+	h1, m1, s1, fr := ft.fields(frame)
+	sep := byte(':')
+	if ft.dropFrame {
+		sep = ';'
+	}
+	dst = appendPadded(dst, h1)
+	dst = append(dst, ':')
+	dst = appendPadded(dst, m1)
+	dst = append(dst, ':')
+	dst = appendPadded(dst, s1)
+	dst = append(dst, sep)
+	return appendPadded(dst, fr)
 }
 
 func (t *Timecode) sameFrameRate(ta Timecode) bool {
@@ -344,6 +494,39 @@ func (t *Timecode) sameFrameRate(ta Timecode) bool {
 	return true
 }
 
+// checkChar verifies the character at the offset `i` of the timecode string HH:MM:SS:FF or HH:MM:SS;FF.
+func checkChar[T string | []byte](ts T, i int) error {
+	const cLayout = "00:00:00:00"
+	var field Field
+	switch {
+	case cLayout[i] == ':':
+		field = FieldSeparator
+	default:
+		field = Field(i / 3) //nolint:gomnd
+	}
+	if i >= len(ts) {
+		return newParseError(string(ts), field, i, ReasonSyntax)
+	}
+	c := ts[i]
+	ok := c >= '0' && c <= '9'
+	if field == FieldSeparator {
+		ok = c == ':' || (c == ';' && i == len(cLayout)-3)
+	}
+	if !ok {
+		return newParseError(string(ts), field, i, ReasonSyntax)
+	}
+	return nil
+}
+
+// isDropped returns true if the label with the given minutes, seconds and frames is skipped by drop frame.
+func isDropped(m1 int, s1 int, f int) bool {
+	const (
+		cDropped = 2
+		cTenMin  = 10
+	)
+	return s1 == 0 && f < cDropped && m1%cTenMin != 0
+}
+
 func extractMin(r1 rune, r2 rune) int {
 	m1 := isDecimalMinSec(r1)
 	m2 := isDigit(r2)
@@ -364,8 +547,43 @@ func isDecimalMinSec(r rune) int {
 	return int(r - '0')
 }
 
-func cast2Int(x float64) int {
-	return int(math.Trunc(x))
+// ratio returns the frame rate as the fraction num/den, e.g., 30000/1001 for 29.97.  The
+// denominator is the first of 1, 1001 and 1000 that gives an integer numerator.  Other frame rates
+// are approximated to the millionth.
+func ratio(fps float64) (num uint64, den uint64) {
+	const cEpsilon = 1e-6
+	for _, den = range [...]uint64{1, 1001, 1000} {
+		x := fps * float64(den)
+		if math.Abs(x-math.Round(x)) < cEpsilon {
+			return uint64(math.Round(x)), den
+		}
+	}
+	return uint64(math.Round(fps * cMicro)), cMicro
+}
+
+// framesIn returns the number of frames in the given number of seconds at the rate num/den rounded to
+// the nearest frame.
+func framesIn(num uint64, den uint64, seconds int) int {
+	return int((uint64(seconds)*num + den/2) / den)
+}
+
+// mulDiv returns a*b/c rounded down without intermediate overflow.  It returns false if the result does
+// not fit in 64 bits.
+func mulDiv(a uint64, b uint64, c uint64) (uint64, bool) {
+	hi, lo := bits.Mul64(a, b)
+	if hi >= c {
+		return 0, false
+	}
+	q, _ := bits.Div64(hi, lo, c)
+	return q, true
+}
+
+// appendPadded appends the decimal representation of `v` padded with zeros to two digits.
+func appendPadded(dst []byte, v int) []byte {
+	if v >= 0 && v < 10 {
+		dst = append(dst, '0')
+	}
+	return strconv.AppendInt(dst, int64(v), 10)
 }
 
 func cast2Round(x float64) int {
@@ -6,7 +6,9 @@ package timecode
 
 import (
 	"fmt"
+	"math"
 	"math/rand"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -70,6 +72,8 @@ func TestNew(t *testing.T) {
 
 		{-1, FPS2997, 0, false},
 		{59, -24, 0, false},
+		{3e10, cFPS25, 0, false},
+		{1e300, cFPS25, 0, false},
 	}
 	for i, tt := range tests {
 		tc, err := New(tt.fps, tt.sec)
@@ -183,6 +187,11 @@ func TestTimecode_AsMilliseconds(t *testing.T) {
 		{"00:01:00:00", cFPS25, "00:01:00.000"},
 		{"01:00:00:00", cFPS25, "01:00:00.000"},
 		{"02:02:03:24", cFPS25, "02:02:03.960"},
+		{"00:00:00:01", FPS23976fps, "00:00:00.042"},
+		{"00:00:00:11", FPS23976fps, "00:00:00.459"},
+		{"00:00:41:16", FPS23976fps, "00:00:41.708"},
+		{"00:00:00:02", FPS2997, "00:00:00.067"},
+		{"00:00:00:07", FPS2997, "00:00:00.234"},
 	}
 	for _, tt := range tests {
 		tc, err := NewFromString(tt.fps, tt.time)
@@ -391,7 +400,48 @@ func TestTimecode_Milliseconds(t *testing.T) {
 
 	tt, _ := NewFromFrame(cFPS25, 25)
 	assert.Equal(1000, tt.Milliseconds())
+	tt, _ = NewFromFrame(FPS2997, 30)
+	assert.Equal(1001, tt.Milliseconds())
+	tt, _ = NewFromFrame(FPS23976fps, 1)
+	assert.Equal(41, tt.Milliseconds())
+	tt, _ = NewFromFrame(cFPS25, math.MaxInt/100)
+	assert.Equal(math.MaxInt/100*40, tt.Milliseconds())
+	tt, _ = New(FPS2997, 1.001)
+	assert.Equal(30, tt.Frame())
+	tt, _ = New(cFPS25, 0.7)
+	assert.Equal(17, tt.Frame())
+}
+
+func TestTimecode_AppendFormat(t *testing.T) {
+	_, assert := Describe(t)
 
+	tc, _ := NewFromString(cFPS25, "12:34:56:22")
+	assert.Equal("tc=12:34:56:22", string(tc.AppendFormat([]byte("tc="))))
+	tc, _ = NewWithDropFrameFromString("01:00:00;02")
+	assert.Equal("01:00:00;02", string(tc.AppendFormat(nil)))
+	tc, _ = NewFromFrame(cFPS25, 100*cModulo24H*25/24)
+	assert.Equal("100:00:00:00", tc.String())
+
+	buf := make([]byte, 0, 32)
+	assert.Zero(testing.AllocsPerRun(100, func() { buf = tc.AppendFormat(buf[:0]) }))
+}
+
+func TestTimecode_ParseBytes(t *testing.T) {
+	require, assert := Describe(t)
+
+	tc, _ := NewFromFrame(cFPS25, 0)
+	require.NoError(tc.ParseBytes([]byte("12:34:56:22")))
+	assert.Equal(1132422, tc.Frame())
+	var pe *ParseError
+	require.ErrorAs(tc.ParseBytes([]byte("12:64:56:22")), &pe)
+	assert.Equal("12:64:56:22", pe.Input)
+	df, _ := NewWithDropFrame(0)
+	require.NoError(df.ParseBytes([]byte("00:01:00;02")))
+	assert.Equal(1800, df.Frame())
+
+	b := []byte("12:34:56;22")
+	assert.Zero(testing.AllocsPerRun(100, func() { _ = df.ParseBytes(b) }))
+	assert.Zero(testing.AllocsPerRun(100, func() { _ = df.Milliseconds() }))
 }
 func TestTimecode_Before(t *testing.T) {
 	_, assert := Describe(t)
@@ -415,6 +465,83 @@ func TestTimecode_Convert(t *testing.T) {
 	assert.Equal(cFPS24, t2.fps)
 }
 
+func BenchmarkTimecode_Parse(b *testing.B) {
+	tc, _ := NewWithDropFrame(0)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = tc.Parse("12:34:56;22")
+	}
+}
+
+func BenchmarkTimecode_ParseBytes(b *testing.B) {
+	tc, _ := NewWithDropFrame(0)
+	ts := []byte("12:34:56;22")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = tc.ParseBytes(ts)
+	}
+}
+
+func BenchmarkLegacyParse(b *testing.B) {
+	tc, _ := NewWithDropFrame(0)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = legacyParse(tc, "12:34:56;22")
+	}
+}
+
+func BenchmarkTimecode_String(b *testing.B) {
+	tc, _ := NewWithDropFrameFromString("12:34:56;22")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = tc.String()
+	}
+}
+
+func BenchmarkTimecode_AppendFormat(b *testing.B) {
+	tc, _ := NewWithDropFrameFromString("12:34:56;22")
+	buf := make([]byte, 0, 32)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = tc.AppendFormat(buf[:0])
+	}
+}
+
+func BenchmarkLegacyString(b *testing.B) {
+	tc, _ := NewWithDropFrameFromString("12:34:56;22")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		h1, m1, s1, fr := tc.fields()
+		_ = fmt.Sprintf("%02d:%02d:%02d;%02d", h1, m1, s1, fr)
+	}
+}
+
+func BenchmarkTimecode_Milliseconds(b *testing.B) {
+	tc, _ := NewWithDropFrameFromString("12:34:56;22")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = tc.Milliseconds()
+	}
+}
+
+func BenchmarkNew(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = New(FPS2997, 45296.789)
+	}
+}
+
+// legacyParse is the former implementation of Parse that compiled a regular expression per call.  It
+// is the baseline of the parsing benchmarks.
+func legacyParse(t *Timecode, ts string) error {
+	if !regexp.MustCompile(`^\d{2}:[0-5]\d:[0-5]\d[:;][0-2]\d$`).MatchString(ts) {
+		return ErrInvalidTimeCode
+	}
+	tsa := []rune(ts)
+	return t.setFields(extractHour(tsa[0], tsa[1]), extractMin(tsa[3], tsa[4]), extractMin(tsa[6], tsa[7]),
+		extractMin(tsa[9], tsa[10]))
+}
+
 func ExampleTimecode_Add() {
 	t1, _ := NewWithDropFrame(0)
 	t1.SetFrame(44970)
@@ -0,0 +1,70 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+)
+
+var (
+	_reSRT    = regexp.MustCompile(`^(\d{2,}):([0-5]\d):([0-5]\d),(\d{3})$`)
+	_reWebVTT = regexp.MustCompile(`^(?:(\d{2,}):)?([0-5]\d):([0-5]\d)\.(\d{3})$`)
+)
+
+// NewFromMilliseconds initializes a Timecode structure with the given fps at the frame the closest to
+// `ms` milliseconds.
+func NewFromMilliseconds(fps float64, ms int) (*Timecode, error) {
+	if fps <= 0.0 || ms < 0 {
+		return nil, ErrInvalidFPS
+	}
+	// Twice the frame rounded down gives the frame rounded half up.
+	num, den := ratio(fps)
+	frame2, ok := mulDiv(uint64(ms), 2*num, den*cPrecision)
+	frame := frame2/2 + frame2%2
+	if !ok || frame > math.MaxInt {
+		return nil, ErrInvalidFPS
+	}
+	return &Timecode{fps: fps, currentFrame: int(frame)}, nil
+}
+
+// NewFromSRT initializes a Timecode structure with the given fps and a SubRip timestamp HH:MM:SS,mmm.
+// The timecode is at the frame the closest to the timestamp.
+func NewFromSRT(fps float64, ts string) (*Timecode, error) {
+	return newFromTimestamp(fps, _reSRT, ts)
+}
+
+// NewFromWebVTT initializes a Timecode structure with the given fps and a WebVTT timestamp HH:MM:SS.mmm
+// or MM:SS.mmm.  The timecode is at the frame the closest to the timestamp.
+func NewFromWebVTT(fps float64, ts string) (*Timecode, error) {
+	return newFromTimestamp(fps, _reWebVTT, ts)
+}
+
+// AsSRT returns the timecode as a SubRip timestamp HH:MM:SS,mmm.
+func (t *Timecode) AsSRT() string {
+	h1, m1, s1, ms := t.parse()
+	return fmt.Sprintf("%02d:%02d:%02d,%03d", h1, m1, s1, ms)
+}
+
+// AsWebVTT returns the timecode as a WebVTT timestamp HH:MM:SS.mmm.
+func (t *Timecode) AsWebVTT() string {
+	return t.AsMilliseconds()
+}
+
+func newFromTimestamp(fps float64, re *regexp.Regexp, ts string) (*Timecode, error) {
+	const (
+		cHour = iota + 1
+		cMin
+		cSec
+		cMs
+	)
+	m := re.FindStringSubmatch(ts)
+	if m == nil {
+		return nil, ErrInvalidTimeCode
+	}
+	ms := ((atoi(m[cHour])*cNumSec+atoi(m[cMin]))*cNumSec+atoi(m[cSec]))*cPrecision + atoi(m[cMs])
+	return NewFromMilliseconds(fps, ms)
+}
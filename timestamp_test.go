@@ -0,0 +1,117 @@
+// v0.1.0
+// Author: Wunderbarb
+// Oct 2026
+
+package timecode
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewFromSRT(t *testing.T) {
+	require, assert := Describe(t)
+
+	tests := []struct {
+		ts         string
+		fps        float64
+		expFrame   int
+		expSuccess bool
+	}{
+		{"00:00:00,000", cFPS25, 0, true},
+		{"00:01:02,345", cFPS25, 1559, true},
+		{"00:00:01,001", FPS2997, 30, true},
+		{"01:00:00,000", cFPS24, 86400, true},
+		{"00:01:02.345", cFPS25, 0, false},
+		{"00:61:02,345", cFPS25, 0, false},
+		{"00:01:02,345", 0, 0, false},
+	}
+	for i, tt := range tests {
+		tc, err := NewFromSRT(tt.fps, tt.ts)
+		require.Equal(tt.expSuccess, err == nil, "sample %d", i+1)
+		if err == nil {
+			assert.Equal(tt.expFrame, tc.Frame(), "sample %d", i+1)
+		}
+	}
+}
+
+func TestNewFromWebVTT(t *testing.T) {
+	require, assert := Describe(t)
+
+	tests := []struct {
+		ts         string
+		expFrame   int
+		expSuccess bool
+	}{
+		{"00:01:02.345", 1559, true},
+		{"01:02.345", 1559, true},
+		{"00:01:02,345", 0, false},
+	}
+	for i, tt := range tests {
+		tc, err := NewFromWebVTT(cFPS25, tt.ts)
+		require.Equal(tt.expSuccess, err == nil, "sample %d", i+1)
+		if err == nil {
+			assert.Equal(tt.expFrame, tc.Frame(), "sample %d", i+1)
+		}
+	}
+}
+
+func TestTimecode_AsSRT(t *testing.T) {
+	_, assert := Describe(t)
+
+	tests := []struct {
+		frame  int
+		fps    float64
+		expSRT string
+		expVTT string
+	}{
+		{0, cFPS25, "00:00:00,000", "00:00:00.000"},
+		{1559, cFPS25, "00:01:02,360", "00:01:02.360"},
+		{30, FPS2997, "00:00:01,001", "00:00:01.001"},
+		{1, FPS23976fps, "00:00:00,042", "00:00:00.042"},
+		{5, FPS2997, "00:00:00,167", "00:00:00.167"},
+	}
+	for i, tt := range tests {
+		tc, _ := NewFromFrame(tt.fps, tt.frame)
+		assert.Equal(tt.expSRT, tc.AsSRT(), "sample %d", i+1)
+		assert.Equal(tt.expVTT, tc.AsWebVTT(), "sample %d", i+1)
+		tc1, _ := NewFromSRT(tt.fps, tc.AsSRT())
+		assert.Equal(tt.frame, tc1.Frame(), "sample %d", i+1)
+	}
+	_, err := NewFromMilliseconds(cFPS25, -1)
+	assert.ErrorIs(err, ErrInvalidFPS)
+}
+
+func TestNewFromMilliseconds(t *testing.T) {
+	require, assert := Describe(t)
+
+	tests := []struct {
+		fps      float64
+		ms       int
+		expFrame int
+	}{
+		{cFPS25, 19, 0},
+		{cFPS25, 20, 1},
+		{cFPS25, 60, 2},
+		{FPS2997, 1001, 30},
+		{FPS2997, 2000000000, 59940060},
+		{FPS23976fps, 3600000, 86314},
+		{FPS23976fps, 2000000000, 47952048},
+	}
+	for i, tt := range tests {
+		tc, err := NewFromMilliseconds(tt.fps, tt.ms)
+		require.NoError(err, "sample %d", i+1)
+		assert.Equal(tt.expFrame, tc.Frame(), "sample %d", i+1)
+	}
+	// The milliseconds of a frame convert back to the frame.
+	for _, fps := range []float64{FPS23976fps, FPS2997} {
+		for f := 0; f < 100000; f += 997 {
+			tc, _ := NewFromFrame(fps, f)
+			tc1, err := NewFromMilliseconds(fps, tc.Milliseconds())
+			require.NoError(err)
+			assert.Equal(f, tc1.Frame())
+		}
+	}
+	_, err := NewFromMilliseconds(1e6, math.MaxInt)
+	assert.ErrorIs(err, ErrInvalidFPS)
+}